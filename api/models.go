@@ -0,0 +1,194 @@
+package api
+
+import (
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+)
+
+// ReleaseList is the v1 representation of a page of release calendar search results
+type ReleaseList struct {
+	Count      int              `json:"count"`
+	Limit      int              `json:"limit"`
+	Page       int              `json:"page"`
+	TotalPages int              `json:"total_pages"`
+	Breakdown  Breakdown        `json:"breakdown"`
+	Links      PaginationLinks  `json:"links"`
+	Items      []ReleaseSummary `json:"items"`
+}
+
+// Breakdown holds the number of releases in each publication state matching the search, ignoring the release type filter
+type Breakdown struct {
+	Published   int `json:"published"`
+	Upcoming    int `json:"upcoming"`
+	Provisional int `json:"provisional"`
+	Confirmed   int `json:"confirmed"`
+	Postponed   int `json:"postponed"`
+	Cancelled   int `json:"cancelled"`
+	Census      int `json:"census"`
+}
+
+// PaginationLinks holds links to the pages of a ReleaseList. Next and Prev are omitted at either end of the range.
+type PaginationLinks struct {
+	Self  Link  `json:"self"`
+	First Link  `json:"first"`
+	Last  Link  `json:"last"`
+	Next  *Link `json:"next,omitempty"`
+	Prev  *Link `json:"prev,omitempty"`
+}
+
+// Link is a link to another resource
+type Link struct {
+	HRef string `json:"href"`
+}
+
+// ReleaseSummary is the v1 representation of a release in a ReleaseList
+type ReleaseSummary struct {
+	URI              string           `json:"uri"`
+	Title            string           `json:"title"`
+	Summary          string           `json:"summary"`
+	ReleaseDate      string           `json:"release_date"`
+	ProvisionalDate  string           `json:"provisional_date,omitempty"`
+	PublicationState PublicationState `json:"publication_state"`
+	DateChanges      []DateChange     `json:"date_changes"`
+	Links            ReleaseLinks     `json:"links"`
+}
+
+// ReleaseLinks holds the links for a release
+type ReleaseLinks struct {
+	Self Link `json:"self"`
+	HTML Link `json:"html"`
+}
+
+// PublicationState is the state of a release. Type is one of 'published', 'upcoming' or 'cancelled', and the
+// SubType of an upcoming release is one of 'provisional', 'confirmed' or 'postponed'.
+type PublicationState struct {
+	Type    string `json:"type"`
+	SubType string `json:"sub_type,omitempty"`
+}
+
+// DateChange is a previous release date of a release and the reason it was changed
+type DateChange struct {
+	PreviousDate string `json:"previous_date"`
+	ChangeNotice string `json:"change_notice"`
+}
+
+// Release is the v1 representation of a single release
+type Release struct {
+	URI                       string           `json:"uri"`
+	Title                     string           `json:"title"`
+	Summary                   string           `json:"summary"`
+	ReleaseDate               string           `json:"release_date"`
+	ProvisionalDate           string           `json:"provisional_date,omitempty"`
+	NextRelease               string           `json:"next_release,omitempty"`
+	PublicationState          PublicationState `json:"publication_state"`
+	CancellationNotice        []string         `json:"cancellation_notice,omitempty"`
+	NationalStatistic         bool             `json:"national_statistic"`
+	WelshStatistic            bool             `json:"welsh_statistic"`
+	Census                    bool             `json:"census"`
+	Contact                   Contact          `json:"contact"`
+	DateChanges               []DateChange     `json:"date_changes"`
+	RelatedDocuments          []RelatedLink    `json:"related_documents"`
+	RelatedDatasets           []RelatedLink    `json:"related_datasets"`
+	RelatedAPIDatasets        []RelatedLink    `json:"related_api_datasets"`
+	RelatedMethodology        []RelatedLink    `json:"related_methodology"`
+	RelatedMethodologyArticle []RelatedLink    `json:"related_methodology_articles"`
+	Links                     ReleaseLinks     `json:"links"`
+}
+
+// Contact holds the contact details for a release
+type Contact struct {
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Telephone string `json:"telephone"`
+}
+
+// RelatedLink is a link to content related to a release
+type RelatedLink struct {
+	Title   string `json:"title"`
+	URI     string `json:"uri"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// BulkRelease is a line of the bulk export. Passing Cursor to the export resumes it after this release.
+type BulkRelease struct {
+	Cursor  string         `json:"cursor"`
+	Release ReleaseSummary `json:"release"`
+}
+
+// Problem is an RFC 9457 problem details body, returned by the JSON endpoints when a request fails
+type Problem struct {
+	Type   string         `json:"type"`
+	Title  string         `json:"title"`
+	Status int            `json:"status"`
+	Detail string         `json:"detail,omitempty"`
+	Errors []ProblemError `json:"errors,omitempty"`
+}
+
+// ProblemError describes a query parameter that failed validation
+type ProblemError struct {
+	Parameter string `json:"parameter"`
+	Code      string `json:"code"`
+	Detail    string `json:"detail"`
+}
+
+// NewReleaseSummary creates a ReleaseSummary from a calendar entry. selfPath and htmlPath are the paths of the
+// v1 and page representations of the release.
+func NewReleaseSummary(entry model.CalendarEntry, selfPath, htmlPath string) ReleaseSummary {
+	return ReleaseSummary{
+		URI:              entry.URI,
+		Title:            entry.Description.Title,
+		Summary:          entry.Description.Summary,
+		ReleaseDate:      entry.Description.ReleaseDate,
+		ProvisionalDate:  entry.Description.ProvisionalDate,
+		PublicationState: PublicationState(entry.PublicationState),
+		DateChanges:      newDateChanges(entry.DateChanges),
+		Links: ReleaseLinks{
+			Self: Link{HRef: selfPath},
+			HTML: Link{HRef: htmlPath},
+		},
+	}
+}
+
+// NewRelease creates a Release from a release page model. selfPath and htmlPath are the paths of the v1 and page
+// representations of the release.
+func NewRelease(release model.Release, selfPath, htmlPath string) Release {
+	return Release{
+		URI:                       release.URI,
+		Title:                     release.Description.Title,
+		Summary:                   release.Description.Summary,
+		ReleaseDate:               release.Description.ReleaseDate,
+		ProvisionalDate:           release.Description.ProvisionalDate,
+		NextRelease:               release.Description.NextRelease,
+		PublicationState:          PublicationState(release.PublicationState),
+		CancellationNotice:        release.Description.CancellationNotice,
+		NationalStatistic:         release.Description.NationalStatistic,
+		WelshStatistic:            release.Description.WelshStatistic,
+		Census:                    release.Description.Census2021,
+		Contact:                   Contact(release.Description.Contact),
+		DateChanges:               newDateChanges(release.DateChanges),
+		RelatedDocuments:          newRelatedLinks(release.RelatedDocuments),
+		RelatedDatasets:           newRelatedLinks(release.RelatedDatasets),
+		RelatedAPIDatasets:        newRelatedLinks(release.RelatedAPIDatasets),
+		RelatedMethodology:        newRelatedLinks(release.RelatedMethodology),
+		RelatedMethodologyArticle: newRelatedLinks(release.RelatedMethodologyArticle),
+		Links: ReleaseLinks{
+			Self: Link{HRef: selfPath},
+			HTML: Link{HRef: htmlPath},
+		},
+	}
+}
+
+func newDateChanges(changes []model.DateChange) []DateChange {
+	res := make([]DateChange, 0, len(changes))
+	for _, dc := range changes {
+		res = append(res, DateChange{PreviousDate: dc.Date, ChangeNotice: dc.ChangeNotice})
+	}
+	return res
+}
+
+func newRelatedLinks(links []model.Link) []RelatedLink {
+	res := make([]RelatedLink, 0, len(links))
+	for _, l := range links {
+		res = append(res, RelatedLink(l))
+	}
+	return res
+}
@@ -0,0 +1,8 @@
+package api
+
+import _ "embed"
+
+// Spec is the OpenAPI document describing the JSON endpoints of the service
+//
+//go:embed openapi.json
+var Spec []byte
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type specParameter struct {
+	Name   string `json:"name"`
+	In     string `json:"in"`
+	Schema struct {
+		Type      string   `json:"type"`
+		Enum      []string `json:"enum"`
+		MaxLength *int     `json:"maxLength"`
+		Items     struct {
+			Enum []string `json:"enum"`
+		} `json:"items"`
+	} `json:"schema"`
+}
+
+func TestSpec(t *testing.T) {
+	Convey("Given the embedded OpenAPI document", t, func() {
+		var spec struct {
+			OpenAPI    string `json:"openapi"`
+			Paths      map[string]json.RawMessage
+			Components struct {
+				Parameters map[string]specParameter `json:"parameters"`
+			} `json:"components"`
+		}
+		So(json.Unmarshal(Spec, &spec), ShouldBeNil)
+
+		Convey("Then it is an OpenAPI 3 document describing the JSON endpoints", func() {
+			So(spec.OpenAPI, ShouldStartWith, "3.")
+			So(spec.Paths, ShouldContainKey, "/releasecalendar/data")
+			So(spec.Paths, ShouldContainKey, "/releases/{uri}/data")
+			So(spec.Paths, ShouldContainKey, "/v1/releasecalendar")
+			So(spec.Paths, ShouldContainKey, "/v1/releases/{uri}")
+		})
+
+		Convey("Then the query parameters match those read by queryparams", func() {
+			names := make([]string, 0, len(spec.Components.Parameters))
+			for key, p := range spec.Components.Parameters {
+				So(p.Name, ShouldEqual, key)
+				So(p.In, ShouldEqual, "query")
+				names = append(names, p.Name)
+			}
+
+			So(names, ShouldHaveLength, 19)
+			So(names, ShouldContain, queryparams.Limit)
+			So(names, ShouldContain, queryparams.Page)
+			So(names, ShouldContain, queryparams.Keywords)
+			So(names, ShouldContain, queryparams.SortName)
+			So(names, ShouldContain, queryparams.Type)
+			So(names, ShouldContain, queryparams.Provisional.Name())
+			So(names, ShouldContain, queryparams.Confirmed.Name())
+			So(names, ShouldContain, queryparams.Postponed.Name())
+			So(names, ShouldContain, queryparams.Census)
+			So(names, ShouldContain, queryparams.Highlight)
+			So(names, ShouldContain, queryparams.DateRange)
+			So(names, ShouldContain, queryparams.DateFrom)
+			So(names, ShouldContain, queryparams.DateTo)
+			So(names, ShouldContain, queryparams.YearAfter)
+			So(names, ShouldContain, queryparams.MonthAfter)
+			So(names, ShouldContain, queryparams.DayAfter)
+			So(names, ShouldContain, queryparams.YearBefore)
+			So(names, ShouldContain, queryparams.MonthBefore)
+			So(names, ShouldContain, queryparams.DayBefore)
+		})
+
+		Convey("Then the documented values match those accepted by queryparams", func() {
+			So(spec.Components.Parameters[queryparams.SortName].Schema.Enum, ShouldResemble, []string{
+				queryparams.RelDateDesc.String(),
+				queryparams.RelDateAsc.String(),
+				queryparams.TitleAZ.String(),
+				queryparams.TitleZA.String(),
+				queryparams.Relevance.String(),
+			})
+			So(spec.Components.Parameters[queryparams.Type].Schema.Items.Enum, ShouldResemble, []string{
+				queryparams.Published.Name(),
+				queryparams.Upcoming.Name(),
+				queryparams.Cancelled.Name(),
+			})
+
+			presets := make([]string, 0, len(queryparams.DatePresets))
+			for _, p := range queryparams.DatePresets {
+				presets = append(presets, p.String())
+			}
+			So(spec.Components.Parameters[queryparams.DateRange].Schema.Enum, ShouldResemble, presets)
+
+			So(*spec.Components.Parameters[queryparams.Keywords].Schema.MaxLength, ShouldEqual, queryparams.MaxKeywordsLength)
+		})
+	})
+}
@@ -2,44 +2,61 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
 
 	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	"github.com/ONSdigital/dp-frontend-release-calendar/sitemap"
 )
 
 type Config struct {
-	APIRouterURL                string `envconfig:"API_ROUTER_URL"`
-	BindAddr                    string `envconfig:"BIND_ADDR"`
-	Debug                       bool   `envconfig:"DEBUG"`
-	DefaultLimit                int    `envconfig:"DEFAULT_LIMIT"`
-	DefaultMaximumLimit         int    `envconfig:"DEFAULT_MAXIMUM_LIMIT"`
-	DefaultMaximumSearchResults int    `envconfig:"DEFAULT_MAXIMUM_SEARCH_RESULTS"`
-	DefaultSort                 string `envconfig:"DEFAULT_SORT"`
+	APIRouterURL                string           `envconfig:"API_ROUTER_URL"`
+	BindAddr                    string           `envconfig:"BIND_ADDR"`
+	Clock                       func() time.Time `ignored:"true" json:"-"`
+	CursorSecret                string           `envconfig:"CURSOR_SECRET" json:"-"`
+	Debug                       bool             `envconfig:"DEBUG"`
+	DefaultLimit                int              `envconfig:"DEFAULT_LIMIT"`
+	DefaultMaximumLimit         int              `envconfig:"DEFAULT_MAXIMUM_LIMIT"`
+	DefaultMaximumSearchResults int              `envconfig:"DEFAULT_MAXIMUM_SEARCH_RESULTS"`
+	DefaultSort                 string           `envconfig:"DEFAULT_SORT"`
 	Deprecation                 Deprecation
-	FeedbackAPIURL              string        `envconfig:"FEEDBACK_API_URL"`
-	GracefulShutdownTimeout     time.Duration `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT"`
-	HealthCheckCriticalTimeout  time.Duration `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
-	HealthCheckInterval         time.Duration `envconfig:"HEALTHCHECK_INTERVAL"`
-	IsPublishing                bool          `envconfig:"IS_PUBLISHING"`
-	PatternLibraryAssetsPath    string        `envconfig:"PATTERN_LIBRARY_ASSETS_PATH"`
-	RoutingPrefix               string        `envconfig:"ROUTING_PREFIX"`
-	SiteDomain                  string        `envconfig:"SITE_DOMAIN"`
-	SupportedLanguages          []string      `envconfig:"SUPPORTED_LANGUAGES"`
+	Deprecations                []RouteDeprecation `ignored:"true"`
+	DeprecationsFile            string             `envconfig:"DEPRECATIONS_FILE"`
+	FeatureFlags                map[string]int     `envconfig:"FEATURE_FLAGS"`
+	FeedbackAPIURL              string             `envconfig:"FEEDBACK_API_URL"`
+	GracefulShutdownTimeout     time.Duration      `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT"`
+	HealthCheckCriticalTimeout  time.Duration      `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
+	HealthCheckInterval         time.Duration      `envconfig:"HEALTHCHECK_INTERVAL"`
+	IsPublishing                bool               `envconfig:"IS_PUBLISHING"`
+	LimitOptions                []int              `envconfig:"LIMIT_OPTIONS"`
+	PaginationWindowSize        int                `envconfig:"PAGINATION_WINDOW_SIZE"`
+	PatternLibraryAssetsPath    string             `envconfig:"PATTERN_LIBRARY_ASSETS_PATH"`
+	RoutingPrefix               string             `envconfig:"ROUTING_PREFIX"`
+	RuntimeConfigFile           string             `envconfig:"RUNTIME_CONFIG_FILE"`
+	RuntimeConfigPollInterval   time.Duration      `envconfig:"RUNTIME_CONFIG_POLL_INTERVAL"`
+	SiteDomain                  string             `envconfig:"SITE_DOMAIN"`
+	SitemapCacheTTL             time.Duration      `envconfig:"SITEMAP_CACHE_TTL"`
+	SitemapMaxReleases          int                `envconfig:"SITEMAP_MAX_RELEASES"`
+	SitemapPageSize             int                `envconfig:"SITEMAP_PAGE_SIZE"`
+	SupportedLanguages          []string           `envconfig:"SUPPORTED_LANGUAGES"`
 }
 
 type Deprecation struct {
-	DeprecateEndpoint  bool   `envconfig:"DEPRECATE_ENDPOINT"`
-	Deprecation        string `envconfig:"DEPRECATION"`
-	DeprecationMessage string `envconfig:"DEPRECATION_MESSAGE"`
-	Link               string `envconfig:"LINK"`
-	Sunset             string `envconfig:"SUNSET"`
+	DeprecateEndpoint  bool   `envconfig:"DEPRECATE_ENDPOINT" json:"deprecate_endpoint"`
+	Deprecation        string `envconfig:"DEPRECATION" json:"deprecation"`
+	DeprecationMessage string `envconfig:"DEPRECATION_MESSAGE" json:"message"`
+	Link               string `envconfig:"LINK" json:"link"`
+	Sunset             string `envconfig:"SUNSET" json:"sunset"`
 }
 
 var cfg *Config
 
+// localCursorSecret signs paging cursors in local development. It is public, so is rejected on any other site.
+const localCursorSecret = "release-calendar-local-cursor-secret"
+
 var RendererVersion = "v0.2.0"
 
 // Get returns the default config with any modifications through environment
@@ -60,6 +77,10 @@ func Get() (*Config, error) {
 
 	cfg.RoutingPrefix = validateRoutingPrefix(cfg.RoutingPrefix)
 
+	if err = cfg.Validate(); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
@@ -71,6 +92,7 @@ func get() (*Config, error) {
 	cfg = &Config{
 		APIRouterURL:                "http://localhost:23200/v1",
 		BindAddr:                    ":27700",
+		CursorSecret:                localCursorSecret,
 		Debug:                       false,
 		DefaultLimit:                10,
 		DefaultMaximumLimit:         100,
@@ -83,17 +105,37 @@ func get() (*Config, error) {
 			Link:               "",
 			Sunset:             "", // could be of format "2025-08-29T10:00:00Z, 2025-08-29 15:04:05 and 2025-08-29"
 		},
+		FeatureFlags:               map[string]int{},
 		FeedbackAPIURL:             "http://localhost:23200/v1/feedback",
 		GracefulShutdownTimeout:    5 * time.Second,
 		HealthCheckCriticalTimeout: 90 * time.Second,
 		HealthCheckInterval:        30 * time.Second,
 		IsPublishing:               false,
+		LimitOptions:               []int{10, 25},
+		PaginationWindowSize:       5,
 		RoutingPrefix:              "",
+		RuntimeConfigFile:          "",
+		RuntimeConfigPollInterval:  10 * time.Second,
 		SiteDomain:                 "localhost",
+		SitemapCacheTTL:            time.Hour,
+		SitemapMaxReleases:         200000,
+		SitemapPageSize:            sitemap.MaxURLs,
 		SupportedLanguages:         []string{"en", "cy"},
 	}
 
-	return cfg, envconfig.Process("", cfg)
+	if err := envconfig.Process("", cfg); err != nil {
+		return cfg, err
+	}
+
+	if cfg.DeprecationsFile != "" {
+		deprecations, err := LoadDeprecations(cfg.DeprecationsFile)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Deprecations = deprecations
+	}
+
+	return cfg, nil
 }
 
 func validateRoutingPrefix(prefix string) string {
@@ -107,3 +149,36 @@ func validateRoutingPrefix(prefix string) string {
 func (cfg *Config) CalendarPath() string {
 	return cfg.RoutingPrefix + "/releasecalendar"
 }
+
+func (cfg *Config) ICSPath() string {
+	return cfg.RoutingPrefix + "/calendar/releasecalendar"
+}
+
+func (cfg *Config) APIPath() string {
+	return cfg.RoutingPrefix + "/v1"
+}
+
+// Now returns the current time from Clock, which tests set to fix today's date, or from the system clock if it is not set
+func (cfg *Config) Now() time.Time {
+	if cfg.Clock != nil {
+		return cfg.Clock()
+	}
+	return time.Now()
+}
+
+// SiteURL returns the scheme and host of the site that pages in lang are served from. Welsh pages are served from the
+// cy subdomain of SITE_DOMAIN and all others from www, except in local development where SITE_DOMAIN is localhost and
+// pages are served from the port of BIND_ADDR.
+func (cfg *Config) SiteURL(lang string) string {
+	switch {
+	case cfg.SiteDomain == "localhost":
+		if _, port, err := net.SplitHostPort(cfg.BindAddr); err == nil && port != "" {
+			return "http://localhost:" + port
+		}
+		return "http://localhost"
+	case lang == "cy":
+		return "https://cy." + cfg.SiteDomain
+	default:
+		return "https://www." + cfg.SiteDomain
+	}
+}
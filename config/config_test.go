@@ -21,6 +21,7 @@ func TestConfig(t *testing.T) {
 			Convey("Then the values should be set to the expected defaults", func() {
 				So(cfg.APIRouterURL, ShouldEqual, "http://localhost:23200/v1")
 				So(cfg.BindAddr, ShouldEqual, ":27700")
+				So(cfg.CursorSecret, ShouldNotBeEmpty)
 				So(cfg.Debug, ShouldBeFalse)
 				So(cfg.DefaultLimit, ShouldEqual, 10)
 				So(cfg.DefaultMaximumLimit, ShouldEqual, 100)
@@ -31,12 +32,17 @@ func TestConfig(t *testing.T) {
 				So(cfg.Deprecation.DeprecationMessage, ShouldEqual, "")
 				So(cfg.Deprecation.Link, ShouldEqual, "")
 				So(cfg.Deprecation.Sunset, ShouldEqual, "")
+				So(cfg.FeatureFlags, ShouldBeEmpty)
 				So(cfg.GracefulShutdownTimeout, ShouldEqual, 5*time.Second)
 				So(cfg.HealthCheckInterval, ShouldEqual, 30*time.Second)
 				So(cfg.HealthCheckCriticalTimeout, ShouldEqual, 90*time.Second)
 				So(cfg.IsPublishing, ShouldBeFalse)
+				So(cfg.LimitOptions, ShouldResemble, []int{10, 25})
+				So(cfg.PaginationWindowSize, ShouldEqual, 5)
 				So(cfg.PatternLibraryAssetsPath, ShouldEqual, "//cdn.ons.gov.uk/dis-design-system-go/v0.2.0")
 				So(cfg.RoutingPrefix, ShouldEqual, "")
+				So(cfg.RuntimeConfigFile, ShouldEqual, "")
+				So(cfg.RuntimeConfigPollInterval, ShouldEqual, 10*time.Second)
 				So(cfg.SiteDomain, ShouldEqual, "localhost")
 				So(cfg.SupportedLanguages, ShouldResemble, []string{"en", "cy"})
 			})
@@ -61,3 +67,46 @@ func TestValidateRoutingPrefix(t *testing.T) {
 		So(validateRoutingPrefix("/a-prefix"), ShouldEqual, "/a-prefix")
 	})
 }
+
+func TestSiteURL(t *testing.T) {
+	Convey("Given a site domain", t, func() {
+		cfg := &Config{SiteDomain: "ons.gov.uk"}
+
+		Convey("Then English pages are on its www subdomain and Welsh pages on its cy subdomain", func() {
+			So(cfg.SiteURL("en"), ShouldEqual, "https://www.ons.gov.uk")
+			So(cfg.SiteURL("cy"), ShouldEqual, "https://cy.ons.gov.uk")
+		})
+	})
+
+	Convey("Given the local site domain", t, func() {
+		cfg := &Config{SiteDomain: "localhost", BindAddr: ":27700"}
+
+		Convey("Then pages in every language are on the port of localhost that the service is bound to", func() {
+			So(cfg.SiteURL("cy"), ShouldEqual, "http://localhost:27700")
+		})
+
+		Convey("Then pages are on localhost when the bind address has no port", func() {
+			cfg.BindAddr = ""
+			So(cfg.SiteURL("en"), ShouldEqual, "http://localhost")
+		})
+	})
+}
+
+func TestNow(t *testing.T) {
+	Convey("Given a config with a clock", t, func() {
+		now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+		cfg := &Config{Clock: func() time.Time { return now }}
+
+		Convey("Then the current time is read from it", func() {
+			So(cfg.Now(), ShouldEqual, now)
+		})
+	})
+
+	Convey("Given a config without a clock", t, func() {
+		cfg := &Config{}
+
+		Convey("Then the current time is read from the system clock", func() {
+			So(cfg.Now(), ShouldHappenWithin, time.Second, time.Now())
+		})
+	})
+}
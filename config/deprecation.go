@@ -0,0 +1,71 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// legacyDeprecationRoute is the route that the Deprecation environment variables deprecate
+const legacyDeprecationRoute = "/releases/{uri}/data"
+
+// RouteDeprecation is the deprecation policy of a route, given by its path template without the routing prefix. If
+// QueryParam is set, only the requests to the route that have that parameter are deprecated.
+type RouteDeprecation struct {
+	Route      string `json:"route"`
+	QueryParam string `json:"query_param,omitempty"`
+	Deprecation
+}
+
+// String returns the route and query parameter that the policy deprecates
+func (d RouteDeprecation) String() string {
+	if d.QueryParam == "" {
+		return d.Route
+	}
+	return d.Route + "?" + d.QueryParam
+}
+
+// LoadDeprecations reads the route deprecation policies from a JSON file that holds an array of them
+func LoadDeprecations(path string) ([]RouteDeprecation, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read DEPRECATIONS_FILE: %w", err)
+	}
+
+	var deprecations []RouteDeprecation
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&deprecations); err != nil {
+		return nil, fmt.Errorf("unable to parse DEPRECATIONS_FILE %s: %w", path, err)
+	}
+
+	return deprecations, nil
+}
+
+// RouteDeprecations returns the deprecation policies of every route: the one set by the Deprecation environment
+// variables, followed by those loaded from DEPRECATIONS_FILE
+func (cfg *Config) RouteDeprecations() []RouteDeprecation {
+	return append([]RouteDeprecation{{Route: legacyDeprecationRoute, Deprecation: cfg.Deprecation}}, cfg.Deprecations...)
+}
+
+// routeDeprecationProblems checks the policies loaded from DEPRECATIONS_FILE, which must each name a route and may not
+// repeat a route and query parameter
+func (cfg *Config) routeDeprecationProblems() []string {
+	var problems []string
+	seen := map[string]bool{legacyDeprecationRoute: cfg.Deprecation.DeprecateEndpoint}
+	for _, d := range cfg.Deprecations {
+		if !strings.HasPrefix(d.Route, "/") {
+			problems = append(problems, fmt.Sprintf("DEPRECATIONS_FILE route %q must start with /", d.Route))
+		}
+		if seen[d.String()] {
+			problems = append(problems, fmt.Sprintf("DEPRECATIONS_FILE deprecates %s more than once", d))
+		}
+		seen[d.String()] = true
+		for _, p := range d.problems() {
+			problems = append(problems, fmt.Sprintf("DEPRECATIONS_FILE deprecation of %s: %s", d, p))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLoadDeprecations(t *testing.T) {
+	Convey("Given a deprecations file", t, func() {
+		path := filepath.Join(t.TempDir(), "deprecations.json")
+
+		Convey("When it holds policies for a route and a query parameter", func() {
+			err := os.WriteFile(path, []byte(`[
+				{"route": "/releasecalendar/data", "deprecate_endpoint": true, "deprecation": "2026-11-01", "sunset": "2027-05-01", "link": "https://www.ons.gov.uk"},
+				{"route": "/releasecalendar", "query_param": "rss", "deprecate_endpoint": true, "deprecation": "2026-12-01", "sunset": "2027-06-01", "message": "Use /releasecalendar/export.ics"}
+			]`), 0o600)
+			So(err, ShouldBeNil)
+			deprecations, err := LoadDeprecations(path)
+
+			Convey("Then they are loaded", func() {
+				So(err, ShouldBeNil)
+				So(deprecations, ShouldResemble, []RouteDeprecation{
+					{Route: "/releasecalendar/data", Deprecation: Deprecation{DeprecateEndpoint: true, Deprecation: "2026-11-01", Sunset: "2027-05-01", Link: "https://www.ons.gov.uk"}},
+					{Route: "/releasecalendar", QueryParam: "rss", Deprecation: Deprecation{
+						DeprecateEndpoint: true, Deprecation: "2026-12-01", Sunset: "2027-06-01", DeprecationMessage: "Use /releasecalendar/export.ics",
+					}},
+				})
+				So(deprecations[1].String(), ShouldEqual, "/releasecalendar?rss")
+			})
+		})
+
+		Convey("When it has a field that is not known", func() {
+			So(os.WriteFile(path, []byte(`[{"route": "/releasecalendar", "sunset_date": "2027-05-01"}]`), 0o600), ShouldBeNil)
+			_, err := LoadDeprecations(path)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "sunset_date")
+			})
+		})
+
+		Convey("When it does not exist", func() {
+			_, err := LoadDeprecations(path)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestRouteDeprecations(t *testing.T) {
+	Convey("Given deprecations from the environment and a file", t, func() {
+		cfg := &Config{
+			Deprecation:  Deprecation{DeprecateEndpoint: true, Sunset: "2026-01-01"},
+			Deprecations: []RouteDeprecation{{Route: "/calendar/releasecalendar"}},
+		}
+
+		Convey("Then the environment deprecates the release data route, followed by the file", func() {
+			So(cfg.RouteDeprecations(), ShouldResemble, []RouteDeprecation{
+				{Route: "/releases/{uri}/data", Deprecation: cfg.Deprecation},
+				{Route: "/calendar/releasecalendar"},
+			})
+		})
+	})
+}
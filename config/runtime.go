@@ -0,0 +1,178 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// RuntimeConfig holds the settings that are safe to change while the service runs, as read from RUNTIME_CONFIG_FILE.
+// Settings missing from the file keep the values that the service was started with.
+type RuntimeConfig struct {
+	DefaultLimit         *int               `json:"default_limit,omitempty"`
+	DefaultSort          *string            `json:"default_sort,omitempty"`
+	LimitOptions         []int              `json:"limit_options,omitempty"`
+	PaginationWindowSize *int               `json:"pagination_window_size,omitempty"`
+	Deprecation          *Deprecation       `json:"deprecation,omitempty"`
+	Deprecations         []RouteDeprecation `json:"deprecations,omitempty"`
+	FeatureFlags         map[string]int     `json:"feature_flags,omitempty"`
+}
+
+// apply returns a copy of cfg with the settings of rc
+func (rc RuntimeConfig) apply(cfg Config) Config {
+	if rc.DefaultLimit != nil {
+		cfg.DefaultLimit = *rc.DefaultLimit
+	}
+	if rc.DefaultSort != nil {
+		cfg.DefaultSort = *rc.DefaultSort
+	}
+	if rc.LimitOptions != nil {
+		cfg.LimitOptions = slices.Clone(rc.LimitOptions)
+	}
+	if rc.PaginationWindowSize != nil {
+		cfg.PaginationWindowSize = *rc.PaginationWindowSize
+	}
+	if rc.Deprecation != nil {
+		cfg.Deprecation = *rc.Deprecation
+	}
+	if rc.Deprecations != nil {
+		cfg.Deprecations = slices.Clone(rc.Deprecations)
+	}
+	if rc.FeatureFlags != nil {
+		cfg.FeatureFlags = maps.Clone(rc.FeatureFlags)
+	}
+	return cfg
+}
+
+// Store holds the current config, which is the config that the service was started with updated by the settings in
+// RUNTIME_CONFIG_FILE. Reads are atomic, so a handler that takes a snapshot with Get sees either the config before a
+// reload or after it, never part of both.
+type Store struct {
+	base    Config
+	current atomic.Pointer[Config]
+
+	mu      sync.Mutex // serialises reloads
+	modTime time.Time
+	size    int64
+}
+
+// NewStore returns the store of cfg, with the settings of RUNTIME_CONFIG_FILE applied if it is set
+func NewStore(ctx context.Context, cfg *Config) (*Store, error) {
+	s := &Store{base: *cfg}
+	current := *cfg
+	s.current.Store(&current)
+
+	if cfg.RuntimeConfigFile != "" {
+		if err := s.Reload(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Get returns a snapshot of the current config
+func (s *Store) Get() Config {
+	return *s.current.Load()
+}
+
+// Reload reads RUNTIME_CONFIG_FILE and makes its settings current. The settings are rejected, leaving the current
+// config as it was, if the file cannot be read or the config would not be valid.
+func (s *Store) Reload(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.base.RuntimeConfigFile
+	logData := log.Data{"file": path}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		log.Error(ctx, "runtime config rejected", err, logData)
+		return fmt.Errorf("unable to read RUNTIME_CONFIG_FILE: %w", err)
+	}
+	s.modTime, s.size = info.ModTime(), info.Size()
+
+	rc, err := readRuntimeConfig(path)
+	if err != nil {
+		log.Error(ctx, "runtime config rejected", err, logData)
+		return err
+	}
+
+	next := rc.apply(s.base)
+	if err = next.Validate(); err != nil {
+		log.Error(ctx, "runtime config rejected", err, logData)
+		return fmt.Errorf("invalid RUNTIME_CONFIG_FILE %s: %w", path, err)
+	}
+
+	s.current.Store(&next)
+	logData["settings"] = rc
+	log.Info(ctx, "runtime config reloaded", logData)
+	return nil
+}
+
+// Watch reloads RUNTIME_CONFIG_FILE when the service receives SIGHUP, or when the file is found to have changed by
+// checking it every RUNTIME_CONFIG_POLL_INTERVAL, until ctx is done
+func (s *Store) Watch(ctx context.Context) {
+	if s.base.RuntimeConfigFile == "" {
+		return
+	}
+
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	defer signal.Stop(hangups)
+
+	ticker := time.NewTicker(s.base.RuntimeConfigPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangups:
+			log.Info(ctx, "reloading runtime config on SIGHUP")
+			_ = s.Reload(ctx)
+		case <-ticker.C:
+			if s.changed() {
+				log.Info(ctx, "reloading runtime config as the file has changed")
+				_ = s.Reload(ctx)
+			}
+		}
+	}
+}
+
+// changed reports whether RUNTIME_CONFIG_FILE has changed since it was last read
+func (s *Store) changed() bool {
+	info, err := os.Stat(s.base.RuntimeConfigFile)
+	if err != nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !info.ModTime().Equal(s.modTime) || info.Size() != s.size
+}
+
+func readRuntimeConfig(path string) (RuntimeConfig, error) {
+	var rc RuntimeConfig
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return rc, fmt.Errorf("unable to read RUNTIME_CONFIG_FILE: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&rc); err != nil {
+		return rc, fmt.Errorf("unable to parse RUNTIME_CONFIG_FILE %s: %w", path, err)
+	}
+	return rc, nil
+}
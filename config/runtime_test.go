@@ -0,0 +1,107 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given config without a runtime config file", t, func() {
+		cfg := validConfig()
+		store, err := NewStore(ctx, cfg)
+
+		Convey("Then the store holds the config", func() {
+			So(err, ShouldBeNil)
+			So(store.Get(), ShouldResemble, *cfg)
+		})
+	})
+
+	Convey("Given config with a runtime config file", t, func() {
+		cfg := validConfig()
+		cfg.RuntimeConfigFile = filepath.Join(t.TempDir(), "runtime.json")
+		cfg.RuntimeConfigPollInterval = 10 * time.Millisecond
+		So(os.WriteFile(cfg.RuntimeConfigFile, []byte(`{"default_limit": 25, "deprecation": {"deprecate_endpoint": false}}`), 0o600), ShouldBeNil)
+
+		store, err := NewStore(ctx, cfg)
+		So(err, ShouldBeNil)
+
+		Convey("Then the settings in the file are applied to the config", func() {
+			current := store.Get()
+			So(current.DefaultLimit, ShouldEqual, 25)
+			So(current.Deprecation.DeprecateEndpoint, ShouldBeFalse)
+			So(current.DefaultSort, ShouldEqual, cfg.DefaultSort)
+			So(current.LimitOptions, ShouldResemble, cfg.LimitOptions)
+		})
+
+		Convey("When the file is changed and reloaded", func() {
+			So(os.WriteFile(cfg.RuntimeConfigFile, []byte(`{"default_sort": "alphabetical-az", "feature_flags": {"grouping": 20}}`), 0o600), ShouldBeNil)
+			err = store.Reload(ctx)
+
+			Convey("Then the settings of the new file replace those of the old", func() {
+				So(err, ShouldBeNil)
+				So(store.Get().DefaultSort, ShouldEqual, "alphabetical-az")
+				So(store.Get().FeatureFlags, ShouldResemble, map[string]int{"grouping": 20})
+				So(store.Get().DefaultLimit, ShouldEqual, cfg.DefaultLimit)
+			})
+		})
+
+		Convey("When the file is changed to settings that are not valid", func() {
+			So(os.WriteFile(cfg.RuntimeConfigFile, []byte(`{"default_limit": 0}`), 0o600), ShouldBeNil)
+			err = store.Reload(ctx)
+
+			Convey("Then they are rejected and the config is kept", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "DEFAULT_LIMIT (0)")
+				So(store.Get().DefaultLimit, ShouldEqual, 25)
+			})
+		})
+
+		Convey("When the file is changed to a setting that cannot be reloaded", func() {
+			So(os.WriteFile(cfg.RuntimeConfigFile, []byte(`{"bind_addr": ":8080"}`), 0o600), ShouldBeNil)
+			err = store.Reload(ctx)
+
+			Convey("Then it is rejected and the config is kept", func() {
+				So(err, ShouldNotBeNil)
+				So(store.Get().DefaultLimit, ShouldEqual, 25)
+				So(store.Get().BindAddr, ShouldEqual, cfg.BindAddr)
+			})
+		})
+
+		Convey("When the store is watched and the file is changed", func() {
+			watchCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			go store.Watch(watchCtx)
+
+			So(os.WriteFile(cfg.RuntimeConfigFile, []byte(`{"pagination_window_size": 3}`), 0o600), ShouldBeNil)
+
+			Convey("Then the file is reloaded", func() {
+				deadline := time.Now().Add(2 * time.Second)
+				for store.Get().PaginationWindowSize != 3 && time.Now().Before(deadline) {
+					time.Sleep(10 * time.Millisecond)
+				}
+				So(store.Get().PaginationWindowSize, ShouldEqual, 3)
+			})
+		})
+	})
+
+	Convey("Given config with a runtime config file that is not valid", t, func() {
+		cfg := validConfig()
+		cfg.RuntimeConfigFile = filepath.Join(t.TempDir(), "runtime.json")
+		cfg.RuntimeConfigPollInterval = time.Second
+		So(os.WriteFile(cfg.RuntimeConfigFile, []byte(`{"limit_options": [25, 10]}`), 0o600), ShouldBeNil)
+
+		_, err := NewStore(ctx, cfg)
+
+		Convey("Then the store is not created", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "ascending order")
+		})
+	})
+}
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	"github.com/ONSdigital/dp-frontend-release-calendar/sitemap"
+)
+
+// flagName is the form of the name of a feature flag, which can be given in a query or cookie to override it
+var flagName = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// knownLanguages are the languages that the service has locales for
+var knownLanguages = []string{"en", "cy"}
+
+// ValidationError lists every problem found with the config
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate checks the config for values that the service cannot run with, so that it refuses to start rather than
+// failing on each request. Every problem is reported in the returned *ValidationError.
+func (cfg *Config) Validate() error {
+	var problems []string
+	problem := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if cfg.BindAddr == "" {
+		problem("BIND_ADDR must not be empty")
+	}
+	if err := validateURL(cfg.APIRouterURL); err != nil {
+		problem("API_ROUTER_URL %v", err)
+	}
+	if err := validateURL(cfg.FeedbackAPIURL); err != nil {
+		problem("FEEDBACK_API_URL %v", err)
+	}
+	if cfg.CursorSecret == "" {
+		problem("CURSOR_SECRET must not be empty")
+	}
+	if cfg.CursorSecret == localCursorSecret && cfg.SiteDomain != "localhost" {
+		problem("CURSOR_SECRET must be set to a secret of its own when SITE_DOMAIN is not localhost")
+	}
+
+	if cfg.DefaultMaximumLimit < 1 {
+		problem("DEFAULT_MAXIMUM_LIMIT must be at least 1")
+	}
+	if cfg.DefaultLimit < 1 || cfg.DefaultLimit > cfg.DefaultMaximumLimit {
+		problem("DEFAULT_LIMIT (%d) must be between 1 and DEFAULT_MAXIMUM_LIMIT (%d)", cfg.DefaultLimit, cfg.DefaultMaximumLimit)
+	}
+	if cfg.DefaultMaximumSearchResults < 1 {
+		problem("DEFAULT_MAXIMUM_SEARCH_RESULTS must be at least 1")
+	}
+	if _, err := queryparams.ParseSort(cfg.DefaultSort); err != nil {
+		problem("DEFAULT_SORT %q is not a sort order", cfg.DefaultSort)
+	}
+	problems = append(problems, cfg.paginationProblems()...)
+
+	if cfg.GracefulShutdownTimeout <= 0 {
+		problem("GRACEFUL_SHUTDOWN_TIMEOUT must be positive")
+	}
+	if cfg.HealthCheckInterval <= 0 {
+		problem("HEALTHCHECK_INTERVAL must be positive")
+	}
+	if cfg.HealthCheckCriticalTimeout < cfg.HealthCheckInterval {
+		problem("HEALTHCHECK_CRITICAL_TIMEOUT must not be shorter than HEALTHCHECK_INTERVAL")
+	}
+	if cfg.RuntimeConfigFile != "" && cfg.RuntimeConfigPollInterval <= 0 {
+		problem("RUNTIME_CONFIG_POLL_INTERVAL must be positive when RUNTIME_CONFIG_FILE is set")
+	}
+	if cfg.SitemapCacheTTL <= 0 {
+		problem("SITEMAP_CACHE_TTL must be positive")
+	}
+	if cfg.SitemapMaxReleases < 1 {
+		problem("SITEMAP_MAX_RELEASES must be positive")
+	}
+	if cfg.SitemapPageSize < 1 || cfg.SitemapPageSize > sitemap.MaxURLs {
+		problem("SITEMAP_PAGE_SIZE (%d) must be between 1 and %d", cfg.SitemapPageSize, sitemap.MaxURLs)
+	}
+
+	if len(cfg.SupportedLanguages) == 0 {
+		problem("SUPPORTED_LANGUAGES must not be empty")
+	}
+	for i, lang := range cfg.SupportedLanguages {
+		if !slices.Contains(knownLanguages, lang) {
+			problem("SUPPORTED_LANGUAGES value %q must be one of %s", lang, strings.Join(knownLanguages, ", "))
+		}
+		if slices.Contains(cfg.SupportedLanguages[:i], lang) {
+			problem("SUPPORTED_LANGUAGES value %q is repeated", lang)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(cfg.FeatureFlags)) {
+		if !flagName.MatchString(name) {
+			problem("FEATURE_FLAGS name %q must be lower case letters and digits separated by hyphens", name)
+		}
+		if rollout := cfg.FeatureFlags[name]; rollout < 0 || rollout > 100 {
+			problem("FEATURE_FLAGS rollout of %s (%d) must be a percentage from 0 to 100", name, rollout)
+		}
+	}
+
+	problems = append(problems, cfg.Deprecation.problems()...)
+	problems = append(problems, cfg.routeDeprecationProblems()...)
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// paginationProblems checks that the page sizes offered to users, the default page size and the number of page
+// links shown can be used together
+func (cfg *Config) paginationProblems() []string {
+	var problems []string
+	if len(cfg.LimitOptions) == 0 {
+		problems = append(problems, "LIMIT_OPTIONS must not be empty")
+	}
+	for i, limit := range cfg.LimitOptions {
+		if limit < 1 || limit > cfg.DefaultMaximumLimit {
+			problems = append(problems, fmt.Sprintf("LIMIT_OPTIONS value %d must be between 1 and DEFAULT_MAXIMUM_LIMIT (%d)", limit, cfg.DefaultMaximumLimit))
+		}
+		if i > 0 && limit <= cfg.LimitOptions[i-1] {
+			problems = append(problems, "LIMIT_OPTIONS must be in ascending order without repeats")
+		}
+	}
+	if len(cfg.LimitOptions) > 0 && !slices.Contains(cfg.LimitOptions, cfg.DefaultLimit) {
+		problems = append(problems, fmt.Sprintf("DEFAULT_LIMIT (%d) must be one of LIMIT_OPTIONS", cfg.DefaultLimit))
+	}
+	if cfg.PaginationWindowSize < 1 {
+		problems = append(problems, "PAGINATION_WINDOW_SIZE must be at least 1")
+	}
+
+	return problems
+}
+
+// problems checks that a deprecation that is turned on has dates that can be parsed, in order, and a valid link
+func (d Deprecation) problems() []string {
+	if !d.DeprecateEndpoint {
+		return nil
+	}
+
+	var problems []string
+	deprecation, err := ParseTime(d.Deprecation)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("DEPRECATION %q %v", d.Deprecation, err))
+	}
+	sunset, sunsetErr := ParseTime(d.Sunset)
+	if sunsetErr != nil {
+		problems = append(problems, fmt.Sprintf("SUNSET %q %v", d.Sunset, sunsetErr))
+	}
+	if err == nil && sunsetErr == nil && sunset.Before(deprecation) {
+		problems = append(problems, "SUNSET must not be before DEPRECATION")
+	}
+	if d.Link != "" {
+		if err = validateURL(d.Link); err != nil {
+			problems = append(problems, fmt.Sprintf("LINK %v", err))
+		}
+	}
+
+	return problems
+}
+
+// ParseTime parses a deprecation or sunset date, given in RFC 3339, "2006-01-02 15:04:05" or "2006-01-02" format
+func ParseTime(timeStr string) (time.Time, error) {
+	for _, timeFmt := range []string{time.RFC3339, time.DateOnly, time.DateTime} {
+		if parsedTime, err := time.Parse(timeFmt, timeStr); err == nil {
+			return parsedTime, nil
+		}
+	}
+	return time.Time{}, errors.New("is not a date in a supported format")
+}
+
+// validateURL checks that s is an absolute http or https URL
+func validateURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%q must be an absolute http or https URL", s)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func validConfig() *Config {
+	return &Config{
+		APIRouterURL:                "http://localhost:23200/v1",
+		BindAddr:                    ":27700",
+		CursorSecret:                "secret",
+		DefaultLimit:                10,
+		DefaultMaximumLimit:         100,
+		DefaultMaximumSearchResults: 1000,
+		DefaultSort:                 "date-newest",
+		Deprecation: Deprecation{
+			DeprecateEndpoint: true,
+			Deprecation:       "2026-01-01",
+			Sunset:            "2026-06-01T00:00:00Z",
+			Link:              "https://www.ons.gov.uk/releasecalendar",
+		},
+		FeedbackAPIURL:             "http://localhost:23200/v1/feedback",
+		GracefulShutdownTimeout:    5 * time.Second,
+		HealthCheckInterval:        30 * time.Second,
+		HealthCheckCriticalTimeout: 90 * time.Second,
+		LimitOptions:               []int{10, 25},
+		PaginationWindowSize:       5,
+		SitemapCacheTTL:            time.Hour,
+		SitemapMaxReleases:         200000,
+		SitemapPageSize:            50000,
+		SupportedLanguages:         []string{"en", "cy"},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testcases := []struct {
+		name     string
+		change   func(cfg *Config)
+		problems []string
+	}{
+		{name: "nothing wrong", change: func(cfg *Config) {}},
+		{name: "no bind address", change: func(cfg *Config) { cfg.BindAddr = "" }, problems: []string{"BIND_ADDR"}},
+		{name: "a relative API router URL", change: func(cfg *Config) { cfg.APIRouterURL = "/v1" }, problems: []string{"API_ROUTER_URL"}},
+		{name: "a feedback API URL that is not http", change: func(cfg *Config) { cfg.FeedbackAPIURL = "ftp://localhost/feedback" }, problems: []string{"FEEDBACK_API_URL"}},
+		{name: "no cursor secret", change: func(cfg *Config) { cfg.CursorSecret = "" }, problems: []string{"CURSOR_SECRET"}},
+		{name: "the local cursor secret in local development", change: func(cfg *Config) { cfg.CursorSecret = localCursorSecret; cfg.SiteDomain = "localhost" }},
+		{
+			name: "the local cursor secret on a public site", change: func(cfg *Config) { cfg.CursorSecret = localCursorSecret; cfg.SiteDomain = "ons.gov.uk" },
+			problems: []string{"CURSOR_SECRET must be set"},
+		},
+		{
+			name: "no maximum limit", change: func(cfg *Config) { cfg.DefaultMaximumLimit = 0 },
+			problems: []string{"DEFAULT_MAXIMUM_LIMIT must be at least 1", "DEFAULT_LIMIT (10)", "LIMIT_OPTIONS value 10", "LIMIT_OPTIONS value 25"},
+		},
+		{
+			name: "a default limit of zero", change: func(cfg *Config) { cfg.DefaultLimit = 0 },
+			problems: []string{"DEFAULT_LIMIT (0) must be between 1 and DEFAULT_MAXIMUM_LIMIT (100)", "DEFAULT_LIMIT (0) must be one of LIMIT_OPTIONS"},
+		},
+		{
+			name: "a default limit above the maximum", change: func(cfg *Config) { cfg.DefaultLimit = 101 },
+			problems: []string{"DEFAULT_LIMIT (101) must be between", "DEFAULT_LIMIT (101) must be one of LIMIT_OPTIONS"},
+		},
+		{name: "no maximum search results", change: func(cfg *Config) { cfg.DefaultMaximumSearchResults = 0 }, problems: []string{"DEFAULT_MAXIMUM_SEARCH_RESULTS"}},
+		{name: "an unknown default sort", change: func(cfg *Config) { cfg.DefaultSort = "date-oldest-first" }, problems: []string{`DEFAULT_SORT "date-oldest-first"`}},
+		{name: "pagination that cannot be used", change: func(cfg *Config) { cfg.PaginationWindowSize = 0 }, problems: []string{"PAGINATION_WINDOW_SIZE"}},
+		{name: "no graceful shutdown timeout", change: func(cfg *Config) { cfg.GracefulShutdownTimeout = 0 }, problems: []string{"GRACEFUL_SHUTDOWN_TIMEOUT"}},
+		{name: "a negative health check interval", change: func(cfg *Config) { cfg.HealthCheckInterval = -time.Second }, problems: []string{"HEALTHCHECK_INTERVAL"}},
+		{
+			name: "a health check critical timeout shorter than the interval", change: func(cfg *Config) { cfg.HealthCheckCriticalTimeout = 10 * time.Second },
+			problems: []string{"HEALTHCHECK_CRITICAL_TIMEOUT"},
+		},
+		{
+			name: "a runtime config file that is never checked", change: func(cfg *Config) { cfg.RuntimeConfigFile = "runtime.json"; cfg.RuntimeConfigPollInterval = 0 },
+			problems: []string{"RUNTIME_CONFIG_POLL_INTERVAL"},
+		},
+		{name: "sitemaps that are never rebuilt", change: func(cfg *Config) { cfg.SitemapCacheTTL = 0 }, problems: []string{"SITEMAP_CACHE_TTL"}},
+		{name: "unbounded sitemaps", change: func(cfg *Config) { cfg.SitemapMaxReleases = 0 }, problems: []string{"SITEMAP_MAX_RELEASES must be positive"}},
+		{name: "sitemaps over the URL limit", change: func(cfg *Config) { cfg.SitemapPageSize = 50001 }, problems: []string{"SITEMAP_PAGE_SIZE (50001)"}},
+		{name: "feature flags", change: func(cfg *Config) { cfg.FeatureFlags = map[string]int{"grouping": 0, "topic-filter-2": 100} }},
+		{
+			name: "a feature flag name that cannot be given in a query", change: func(cfg *Config) { cfg.FeatureFlags = map[string]int{"Grouping,new": 10} },
+			problems: []string{`FEATURE_FLAGS name "Grouping,new"`},
+		},
+		{
+			name: "a feature flag rollout that is not a percentage", change: func(cfg *Config) { cfg.FeatureFlags = map[string]int{"grouping": 101, "feeds": -1} },
+			problems: []string{"FEATURE_FLAGS rollout of feeds (-1)", "FEATURE_FLAGS rollout of grouping (101)"},
+		},
+		{name: "no supported languages", change: func(cfg *Config) { cfg.SupportedLanguages = nil }, problems: []string{"SUPPORTED_LANGUAGES must not be empty"}},
+		{name: "an unknown language", change: func(cfg *Config) { cfg.SupportedLanguages = []string{"en", "fr"} }, problems: []string{`SUPPORTED_LANGUAGES value "fr" must be one of en, cy`}},
+		{name: "a repeated language", change: func(cfg *Config) { cfg.SupportedLanguages = []string{"en", "en"} }, problems: []string{`SUPPORTED_LANGUAGES value "en" is repeated`}},
+		{name: "a deprecation date that cannot be parsed", change: func(cfg *Config) { cfg.Deprecation.Deprecation = "01/01/2026" }, problems: []string{`DEPRECATION "01/01/2026"`}},
+		{name: "no sunset date", change: func(cfg *Config) { cfg.Deprecation.Sunset = "" }, problems: []string{`SUNSET ""`}},
+		{name: "a sunset before the deprecation", change: func(cfg *Config) { cfg.Deprecation.Sunset = "2025-12-31" }, problems: []string{"SUNSET must not be before DEPRECATION"}},
+		{name: "a relative deprecation link", change: func(cfg *Config) { cfg.Deprecation.Link = "/releasecalendar" }, problems: []string{"LINK"}},
+		{name: "no deprecation link", change: func(cfg *Config) { cfg.Deprecation.Link = "" }},
+		{
+			name: "bad deprecation dates while the endpoint is not deprecated",
+			change: func(cfg *Config) {
+				cfg.Deprecation = Deprecation{DeprecateEndpoint: false, Deprecation: "soon", Sunset: "later"}
+			},
+		},
+		{
+			name: "deprecations for other routes", change: func(cfg *Config) {
+				cfg.Deprecations = []RouteDeprecation{
+					{Route: "/releasecalendar", QueryParam: "rss", Deprecation: Deprecation{DeprecateEndpoint: true, Deprecation: "2026-01-01", Sunset: "2026-06-01"}},
+					{Route: "/releasecalendar", Deprecation: Deprecation{DeprecateEndpoint: true, Deprecation: "2026-01-01", Sunset: "2026-06-01"}},
+				}
+			},
+		},
+		{
+			name: "a deprecation for a route that does not start with /", change: func(cfg *Config) {
+				cfg.Deprecations = []RouteDeprecation{{Route: "releasecalendar"}}
+			},
+			problems: []string{`DEPRECATIONS_FILE route "releasecalendar" must start with /`},
+		},
+		{
+			name: "a route that is deprecated twice", change: func(cfg *Config) {
+				cfg.Deprecations = []RouteDeprecation{{Route: "/releasecalendar", QueryParam: "rss"}, {Route: "/releasecalendar", QueryParam: "rss"}}
+			},
+			problems: []string{"DEPRECATIONS_FILE deprecates /releasecalendar?rss more than once"},
+		},
+		{
+			name: "a file that deprecates the release data route as well as the environment", change: func(cfg *Config) {
+				cfg.Deprecations = []RouteDeprecation{{Route: "/releases/{uri}/data"}}
+			},
+			problems: []string{"DEPRECATIONS_FILE deprecates /releases/{uri}/data more than once"},
+		},
+		{
+			name: "a deprecation from a file with a bad sunset", change: func(cfg *Config) {
+				cfg.Deprecations = []RouteDeprecation{{Route: "/calendar/releasecalendar", Deprecation: Deprecation{DeprecateEndpoint: true, Deprecation: "2026-01-01", Sunset: "soon"}}}
+			},
+			problems: []string{`DEPRECATIONS_FILE deprecation of /calendar/releasecalendar: SUNSET "soon"`},
+		},
+		{
+			name: "several problems",
+			change: func(cfg *Config) {
+				cfg.DefaultLimit = 0
+				cfg.DefaultSort = "newest"
+				cfg.SupportedLanguages = []string{"de"}
+				cfg.Deprecation.Sunset = "tomorrow"
+			},
+			problems: []string{"DEFAULT_LIMIT (0) must be between", "DEFAULT_LIMIT (0) must be one of", `DEFAULT_SORT "newest"`, `SUPPORTED_LANGUAGES value "de"`, `SUNSET "tomorrow"`},
+		},
+	}
+
+	for _, tc := range testcases {
+		Convey("Given config with "+tc.name, t, func() {
+			cfg := validConfig()
+			tc.change(cfg)
+			err := cfg.Validate()
+
+			if len(tc.problems) == 0 {
+				Convey("Then it is valid", func() {
+					So(err, ShouldBeNil)
+				})
+				return
+			}
+			Convey("Then every problem is reported", func() {
+				var validationErr *ValidationError
+				So(errors.As(err, &validationErr), ShouldBeTrue)
+				So(validationErr.Problems, ShouldHaveLength, len(tc.problems))
+				for _, p := range tc.problems {
+					So(err.Error(), ShouldContainSubstring, p)
+				}
+			})
+		})
+	}
+}
+
+func TestValidatePagination(t *testing.T) {
+	testcases := []struct {
+		name         string
+		defaultLimit int
+		options      []int
+		window       int
+		problems     []string
+	}{
+		{name: "the defaults", defaultLimit: 10, options: []int{10, 25}, window: 5},
+		{name: "a single option", defaultLimit: 50, options: []int{50}, window: 1},
+		{name: "no options", defaultLimit: 10, window: 5, problems: []string{"LIMIT_OPTIONS must not be empty"}},
+		{name: "an option above the maximum", defaultLimit: 10, options: []int{10, 200}, window: 5, problems: []string{"LIMIT_OPTIONS value 200"}},
+		{name: "an option below one", defaultLimit: 10, options: []int{0, 10}, window: 5, problems: []string{"LIMIT_OPTIONS value 0"}},
+		{name: "options out of order", defaultLimit: 10, options: []int{25, 10}, window: 5, problems: []string{"ascending order"}},
+		{name: "repeated options", defaultLimit: 10, options: []int{10, 10}, window: 5, problems: []string{"ascending order"}},
+		{name: "a default limit that is not an option", defaultLimit: 20, options: []int{10, 25}, window: 5, problems: []string{"DEFAULT_LIMIT (20)"}},
+		{name: "no window", defaultLimit: 10, options: []int{10, 25}, window: 0, problems: []string{"PAGINATION_WINDOW_SIZE"}},
+		{
+			name: "several problems", defaultLimit: 20, options: []int{200, 10}, window: -1,
+			problems: []string{"LIMIT_OPTIONS value 200", "ascending order", "DEFAULT_LIMIT (20)", "PAGINATION_WINDOW_SIZE"},
+		},
+	}
+
+	for _, tc := range testcases {
+		Convey("Given pagination config with "+tc.name, t, func() {
+			cfg := &Config{DefaultLimit: tc.defaultLimit, DefaultMaximumLimit: 100, LimitOptions: tc.options, PaginationWindowSize: tc.window}
+			problems := strings.Join(cfg.paginationProblems(), "; ")
+
+			if len(tc.problems) == 0 {
+				Convey("Then it is valid", func() {
+					So(problems, ShouldBeEmpty)
+				})
+				return
+			}
+			Convey("Then every problem is reported", func() {
+				for _, p := range tc.problems {
+					So(problems, ShouldContainSubstring, p)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+)
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// NewCSVWriter returns a Writer that writes the export as CSV, starting with a row of column headings
+func NewCSVWriter(w io.Writer) (Writer, error) {
+	cw := &csvWriter{w: csv.NewWriter(w)}
+	if err := cw.w.Write(Columns); err != nil {
+		return nil, err
+	}
+
+	return cw, nil
+}
+
+func (cw *csvWriter) Write(row model.ExportRow) error {
+	record := values(row)
+	for i := range record {
+		record[i] = neutraliseFormula(record[i])
+	}
+
+	return cw.w.Write(record)
+}
+
+func (cw *csvWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *csvWriter) Close() error {
+	return cw.Flush()
+}
+
+// formulaPrefixes are the characters that make a spreadsheet evaluate a cell as a formula
+const formulaPrefixes = "=+-@\t\r"
+
+// neutraliseFormula prefixes a value that a spreadsheet would evaluate as a formula with a quote, so that titles and
+// notices from the search API are always shown as text when the CSV is opened
+func neutraliseFormula(value string) string {
+	if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
@@ -0,0 +1,82 @@
+package export
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+)
+
+// Writer streams calendar export rows to an underlying io.Writer
+type Writer interface {
+	Write(row model.ExportRow) error
+	Flush() error
+	Close() error
+}
+
+// Format describes a supported export file format
+type Format struct {
+	Name        string
+	ContentType string
+	NewWriter   func(w io.Writer) (Writer, error)
+}
+
+var (
+	CSV = Format{
+		Name:        "csv",
+		ContentType: "text/csv; charset=utf-8",
+		NewWriter:   NewCSVWriter,
+	}
+	XLSX = Format{
+		Name:        "xlsx",
+		ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		NewWriter:   NewXLSXWriter,
+	}
+)
+
+// ParseFormat returns the export format with the given name
+func ParseFormat(s string) (Format, error) {
+	for _, f := range []Format{CSV, XLSX} {
+		if strings.EqualFold(s, f.Name) {
+			return f, nil
+		}
+	}
+
+	return Format{}, errors.New("invalid export format string")
+}
+
+// Filename returns the name of the file the export is served as
+func (f Format) Filename() string {
+	return "releases." + f.Name
+}
+
+// Columns are the headings of the export, in the order values are written by each row
+var Columns = []string{
+	"Title",
+	"URI",
+	"Release date",
+	"Provisional date",
+	"Publication state",
+	"Publication sub-state",
+	"Census",
+	"Cancellation notice",
+	"Postponement reason",
+}
+
+const censusColumn = 6
+
+func values(row model.ExportRow) []string {
+	return []string{
+		row.Title,
+		row.URI,
+		row.ReleaseDate,
+		row.ProvisionalDate,
+		row.PublicationState.Type,
+		row.PublicationState.SubType,
+		strconv.FormatBool(row.Census),
+		row.CancellationNotice,
+		row.PostponementReason,
+	}
+}
@@ -0,0 +1,137 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testRow = model.ExportRow{
+	Title:              "Labour market <overview>, UK & Wales",
+	URI:                "/releases/labourmarketoverview",
+	ReleaseDate:        "2026-03-01T07:00:00Z",
+	ProvisionalDate:    "March 2026",
+	PublicationState:   model.PublicationState{Type: "upcoming", SubType: "postponed"},
+	Census:             true,
+	PostponementReason: "Delayed to include \"new\" data",
+}
+
+func TestParseFormat(t *testing.T) {
+	Convey("Given a supported format name", t, func() {
+		Convey("Then the matching format is returned regardless of case", func() {
+			f, err := ParseFormat("CSV")
+			So(err, ShouldBeNil)
+			So(f.Name, ShouldEqual, CSV.Name)
+			So(f.Filename(), ShouldEqual, "releases.csv")
+
+			f, err = ParseFormat("xlsx")
+			So(err, ShouldBeNil)
+			So(f.Name, ShouldEqual, XLSX.Name)
+			So(f.Filename(), ShouldEqual, "releases.xlsx")
+		})
+	})
+
+	Convey("Given an unsupported format name", t, func() {
+		Convey("Then an error is returned", func() {
+			_, err := ParseFormat("pdf")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestCSVWriter(t *testing.T) {
+	Convey("Given a CSV writer", t, func() {
+		buf := new(bytes.Buffer)
+		w, err := NewCSVWriter(buf)
+		So(err, ShouldBeNil)
+
+		Convey("When a row is written and the writer closed", func() {
+			So(w.Write(testRow), ShouldBeNil)
+			So(w.Close(), ShouldBeNil)
+
+			Convey("Then the output is a header row followed by the row values", func() {
+				records, err := csv.NewReader(buf).ReadAll()
+				So(err, ShouldBeNil)
+				So(records, ShouldHaveLength, 2)
+				So(records[0], ShouldResemble, Columns)
+				So(records[1], ShouldResemble, []string{
+					testRow.Title,
+					testRow.URI,
+					testRow.ReleaseDate,
+					testRow.ProvisionalDate,
+					"upcoming",
+					"postponed",
+					"true",
+					"",
+					testRow.PostponementReason,
+				})
+			})
+		})
+
+		Convey("When a row has values that a spreadsheet would evaluate as formulas", func() {
+			row := testRow
+			row.Title = `=HYPERLINK("http://example.com","Click")`
+			row.CancellationNotice = "@SUM(1+1)"
+			row.PostponementReason = "-2+3"
+			So(w.Write(row), ShouldBeNil)
+			So(w.Close(), ShouldBeNil)
+
+			Convey("Then those values are quoted so they are shown as text", func() {
+				records, err := csv.NewReader(buf).ReadAll()
+				So(err, ShouldBeNil)
+				So(records[1][0], ShouldEqual, `'=HYPERLINK("http://example.com","Click")`)
+				So(records[1][1], ShouldEqual, row.URI)
+				So(records[1][7], ShouldEqual, "'@SUM(1+1)")
+				So(records[1][8], ShouldEqual, "'-2+3")
+			})
+		})
+	})
+}
+
+func TestXLSXWriter(t *testing.T) {
+	Convey("Given an XLSX writer", t, func() {
+		buf := new(bytes.Buffer)
+		w, err := NewXLSXWriter(buf)
+		So(err, ShouldBeNil)
+
+		Convey("When a row is written and the writer closed", func() {
+			So(w.Write(testRow), ShouldBeNil)
+			So(w.Close(), ShouldBeNil)
+
+			Convey("Then the output is a workbook containing every part", func() {
+				zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+				So(err, ShouldBeNil)
+
+				names := make([]string, 0, len(zr.File))
+				var sheet string
+				for _, f := range zr.File {
+					names = append(names, f.Name)
+					if f.Name == xlsxSheetName {
+						rc, err := f.Open()
+						So(err, ShouldBeNil)
+						b, err := io.ReadAll(rc)
+						So(err, ShouldBeNil)
+						sheet = string(b)
+					}
+				}
+				So(names, ShouldContain, "[Content_Types].xml")
+				So(names, ShouldContain, "xl/workbook.xml")
+				So(names, ShouldContain, xlsxSheetName)
+
+				Convey("And the sheet contains the escaped header and row", func() {
+					So(strings.Count(sheet, "<row>"), ShouldEqual, 2)
+					So(sheet, ShouldContainSubstring, "Publication sub-state")
+					So(sheet, ShouldContainSubstring, "Labour market &lt;overview&gt;, UK &amp; Wales")
+					So(sheet, ShouldContainSubstring, `<c t="b"><v>1</v></c>`)
+					So(sheet, ShouldEndWith, xlsxSheetEnd)
+				})
+			})
+		})
+	})
+}
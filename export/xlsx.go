@@ -0,0 +1,141 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+)
+
+// The static parts of a minimal single sheet workbook. The worksheet itself is streamed as rows are written.
+var xlsxParts = []struct{ name, content string }{
+	{
+		name: "[Content_Types].xml",
+		content: xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`,
+	},
+	{
+		name: "_rels/.rels",
+		content: xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`,
+	},
+	{
+		name: "xl/workbook.xml",
+		content: xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Releases" sheetId="1" r:id="rId1"/></sheets>` +
+			`</workbook>`,
+	},
+	{
+		name: "xl/_rels/workbook.xml.rels",
+		content: xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`,
+	},
+}
+
+const (
+	xlsxSheetName  = "xl/worksheets/sheet1.xml"
+	xlsxSheetStart = xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd   = `</sheetData></worksheet>`
+)
+
+type xlsxWriter struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+}
+
+// NewXLSXWriter returns a Writer that writes the export as an Office Open XML workbook with a single sheet,
+// starting with a row of column headings
+func NewXLSXWriter(w io.Writer) (Writer, error) {
+	xw := &xlsxWriter{zw: zip.NewWriter(w)}
+
+	for _, part := range xlsxParts {
+		f, err := xw.zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := xw.zw.Create(xlsxSheetName)
+	if err != nil {
+		return nil, err
+	}
+	xw.sheet = bufio.NewWriter(f)
+
+	if _, err = xw.sheet.WriteString(xlsxSheetStart); err != nil {
+		return nil, err
+	}
+	if err = xw.writeRow(Columns, -1); err != nil {
+		return nil, err
+	}
+
+	return xw, nil
+}
+
+func (xw *xlsxWriter) Write(row model.ExportRow) error {
+	return xw.writeRow(values(row), censusColumn)
+}
+
+// writeRow writes a row of inline string cells, except for the cell at boolColumn which is written as a boolean
+func (xw *xlsxWriter) writeRow(cells []string, boolColumn int) error {
+	if _, err := xw.sheet.WriteString("<row>"); err != nil {
+		return err
+	}
+
+	for i, cell := range cells {
+		if i == boolColumn {
+			b := 0
+			if cell == "true" {
+				b = 1
+			}
+			if _, err := fmt.Fprintf(xw.sheet, `<c t="b"><v>%d</v></c>`, b); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := xw.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`); err != nil {
+			return err
+		}
+		if err := xml.EscapeText(xw.sheet, []byte(cell)); err != nil {
+			return err
+		}
+		if _, err := xw.sheet.WriteString(`</t></is></c>`); err != nil {
+			return err
+		}
+	}
+
+	_, err := xw.sheet.WriteString("</row>")
+	return err
+}
+
+func (xw *xlsxWriter) Flush() error {
+	if err := xw.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return xw.zw.Flush()
+}
+
+func (xw *xlsxWriter) Close() error {
+	if _, err := xw.sheet.WriteString(xlsxSheetEnd); err != nil {
+		return err
+	}
+	if err := xw.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return xw.zw.Close()
+}
@@ -0,0 +1,95 @@
+package featureflags
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"hash/fnv"
+	"net/http"
+	"strings"
+)
+
+const (
+	// VisitorCookie identifies a visitor, so that the flags rolled out to a percentage of visitors stay the same for
+	// them
+	VisitorCookie = "release_calendar_visitor"
+	// OverrideCookie keeps the overrides given by OverrideParam for the requests that follow
+	OverrideCookie = "release_calendar_features"
+	// OverrideParam turns flags on or off for testers. Its value is a comma separated list of flag names, each turned
+	// off when prefixed with "-".
+	OverrideParam = "features"
+)
+
+// Flags are the feature flags evaluated for a visitor, keyed by name
+type Flags map[string]bool
+
+// Enabled reports whether the flag is on. Flags that are not known are off.
+func (f Flags) Enabled(name string) bool {
+	return f[name]
+}
+
+type contextKey struct{}
+
+// WithFlags returns ctx carrying the flags
+func WithFlags(ctx context.Context, flags Flags) context.Context {
+	return context.WithValue(ctx, contextKey{}, flags)
+}
+
+// FromContext returns the flags carried by ctx, or no flags if it carries none
+func FromContext(ctx context.Context) Flags {
+	flags, _ := ctx.Value(contextKey{}).(Flags)
+	return flags
+}
+
+// Pin returns r with the flags set to the given values, for tests of the handlers that read them
+func Pin(r *http.Request, flags Flags) *http.Request {
+	return r.WithContext(WithFlags(r.Context(), flags))
+}
+
+// Evaluate returns the flags for a visitor, given the percentage of visitors that each flag is rolled out to. The
+// visitor is placed in a bucket from 0 to 99 for each flag, derived from the visitor ID and the flag name, so that a
+// visitor sees the same flags on every request and each flag is rolled out to a different set of visitors. A flag is
+// on if the bucket is below its rollout. Overrides of known flags are applied in order after the rollout.
+func Evaluate(rollouts map[string]int, visitorID string, overrides ...string) Flags {
+	flags := make(Flags, len(rollouts))
+	for name, rollout := range rollouts {
+		flags[name] = rollout >= 100 || (rollout > 0 && bucket(name, visitorID) < rollout)
+	}
+
+	for _, o := range overrides {
+		for _, name := range strings.Split(o, ",") {
+			name = strings.TrimSpace(name)
+			on := !strings.HasPrefix(name, "-")
+			name = strings.TrimPrefix(name, "-")
+			if _, known := rollouts[name]; known {
+				flags[name] = on
+			}
+		}
+	}
+
+	return flags
+}
+
+// NeedsVisitor reports whether any flag is rolled out to only some visitors, so that the flags depend on who the
+// visitor is
+func NeedsVisitor(rollouts map[string]int) bool {
+	for _, rollout := range rollouts {
+		if rollout > 0 && rollout < 100 {
+			return true
+		}
+	}
+	return false
+}
+
+// NewVisitorID returns a random ID for a visitor without one
+func NewVisitorID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func bucket(name, visitorID string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name + "/" + visitorID))
+	return int(h.Sum32() % 100)
+}
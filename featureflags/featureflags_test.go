@@ -0,0 +1,70 @@
+package featureflags
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestEvaluate(t *testing.T) {
+	Convey("Given flags rolled out to nobody, some visitors and everybody", t, func() {
+		rollouts := map[string]int{"off": 0, "grouping": 30, "on": 100}
+
+		Convey("Then a visitor sees the same flags every time", func() {
+			So(Evaluate(rollouts, "visitor-1"), ShouldResemble, Evaluate(rollouts, "visitor-1"))
+		})
+
+		Convey("Then the flags that are rolled out to nobody or everybody are the same for every visitor", func() {
+			for i := range 100 {
+				flags := Evaluate(rollouts, fmt.Sprint("visitor-", i))
+				So(flags.Enabled("off"), ShouldBeFalse)
+				So(flags.Enabled("on"), ShouldBeTrue)
+			}
+		})
+
+		Convey("Then the flag that is rolled out to some visitors is on for about that share of them", func() {
+			on := 0
+			for i := range 1000 {
+				if Evaluate(rollouts, fmt.Sprint("visitor-", i)).Enabled("grouping") {
+					on++
+				}
+			}
+			So(on, ShouldBeBetween, 250, 350)
+		})
+
+		Convey("Then overrides turn known flags on and off, the last taking precedence", func() {
+			flags := Evaluate(rollouts, "visitor-1", "-on,off", "unknown, -off")
+			So(flags.Enabled("on"), ShouldBeFalse)
+			So(flags.Enabled("off"), ShouldBeFalse)
+			So(flags.Enabled("unknown"), ShouldBeFalse)
+			So(flags, ShouldNotContainKey, "unknown")
+
+			So(Evaluate(rollouts, "visitor-1", "off").Enabled("off"), ShouldBeTrue)
+		})
+
+		Convey("Then a visitor is needed to evaluate them", func() {
+			So(NeedsVisitor(rollouts), ShouldBeTrue)
+			So(NeedsVisitor(map[string]int{"off": 0, "on": 100}), ShouldBeFalse)
+		})
+	})
+}
+
+func TestPin(t *testing.T) {
+	Convey("Given a request with flags pinned", t, func() {
+		r := Pin(httptest.NewRequest(http.MethodGet, "/releasecalendar", http.NoBody), Flags{"grouping": true})
+
+		Convey("Then the flags are read from its context", func() {
+			So(FromContext(r.Context()).Enabled("grouping"), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given a context without flags", t, func() {
+		Convey("Then every flag is off", func() {
+			So(FromContext(context.Background()).Enabled("grouping"), ShouldBeFalse)
+		})
+	})
+}
@@ -170,4 +170,58 @@ Feature: Release Calendar
           {
               ".ons-list__link": "Enter a released before year that is later than 2020"
           }
-      """
\ No newline at end of file
+      """
+
+  Scenario: GET /releasecalendar shows counts for each upcoming subtype
+    Given there is a Search API that gives a successful response with 3 provisional, 2 confirmed and 1 postponed releases
+    And the release calendar is running
+    When I navigate to "/releasecalendar?release-type=type-upcoming"
+    And the page should have the following content
+      """
+          {
+              "#results": "6 results",
+              "label[for=release-type-upcoming]": "Upcoming (6)",
+              "label[for=release-type-provisional]": "Provisional (3)",
+              "label[for=release-type-confirmed]": "Confirmed (2)",
+              "label[for=release-type-postponed]": "Postponed (1)"
+          }
+      """
+
+  Scenario: GET /releasecalendar narrowed to an upcoming subtype
+    Given there is a Search API that gives a successful response with 3 provisional, 2 confirmed and 1 postponed releases
+    And the release calendar is running
+    When I navigate to "/releasecalendar?release-type=type-upcoming&subtype-confirmed=true"
+    Then element "#release-type-confirmed:checked" should be visible
+    And the page should have the following content
+      """
+          {
+              "#results": "2 results",
+              "label[for=release-type-confirmed]": "Confirmed (2)",
+              "label[for=release-type-provisional]": "Provisional (3)"
+          }
+      """
+
+  Scenario: GET /releasecalendar offers no filters for designations the Search API cannot apply
+    Given there is a Search API that gives a successful response and returns 11 results
+    And the release calendar is running
+    When I navigate to "/releasecalendar?accredited-official-statistics=true&welsh-statistics=true"
+    Then element "#release-type-accredited" should not be visible
+    And element "#release-type-welsh" should not be visible
+    And the page should have the following content
+      """
+          {
+              "#results": "11 results"
+          }
+      """
+
+  Scenario: GET /releasecalendar offers no topic filters the Search API cannot apply
+    Given there is a Search API that gives a successful response and returns 11 results
+    And the release calendar is running
+    When I navigate to "/releasecalendar?topics=economy"
+    Then element "#topic-economy" should not be visible
+    And the page should have the following content
+      """
+          {
+              "#results": "11 results"
+          }
+      """
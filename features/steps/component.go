@@ -2,6 +2,7 @@ package steps
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -213,6 +214,55 @@ func generateReleasesResponse(count int) *httpfake.Response {
 	return fakeAPIResponse
 }
 
+// upcomingReleasesHandle responds with the given numbers of upcoming releases in each subtype, returning only the
+// releases of the subtypes requested, if any, as the Search API does
+func upcomingReleasesHandle(provisional, confirmed, postponed int) httpfake.Responder {
+	return func(w http.ResponseWriter, r *http.Request, rh *httpfake.Request) {
+		rh.Lock()
+		defer rh.Unlock()
+
+		subtypes := []struct {
+			name  string
+			count int
+		}{
+			{"subtype-provisional", provisional},
+			{"subtype-confirmed", confirmed},
+			{"subtype-postponed", postponed},
+		}
+
+		count := 0
+		anySelected := false
+		for _, st := range subtypes {
+			anySelected = anySelected || r.URL.Query().Get(st.name) == "true"
+		}
+		for _, st := range subtypes {
+			if !anySelected || r.URL.Query().Get(st.name) == "true" {
+				count += st.count
+			}
+		}
+
+		searchAPIResponse := search.ReleaseResponse{
+			Took: count,
+			Breakdown: search.Breakdown{
+				Total:       count,
+				Provisional: provisional,
+				Confirmed:   confirmed,
+				Postponed:   postponed,
+			},
+			Releases: []search.Release{},
+		}
+		for i := 0; i < count; i++ {
+			searchAPIResponse.Releases = append(searchAPIResponse.Releases, generateReleaseItem(i))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(searchAPIResponse); err != nil {
+			log.Error(r.Context(), "failed to encode fake search response", err)
+		}
+	}
+}
+
 func generateReleaseEntryResponse(releaseEntry releasecalendar.Release) *httpfake.Response {
 	fakeAPIResponse := httpfake.NewResponse()
 	fakeAPIResponse.Status(200)
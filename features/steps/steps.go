@@ -46,6 +46,7 @@ func (c *Component) RegisterSteps(ctx *godog.ScenarioContext) {
 	ctx.Step(`^the downstream service is (healthy|warning|failing)$`, c.theDownstreamServiceStatus)
 	ctx.Step(`^the release calendar is running$`, c.theReleaseCalendarIsRunning)
 	ctx.Step(`^there is a Search API that gives a successful response and returns ([1-9]\d*|0) results`, c.thereIsASearchAPIThatGivesASuccessfulResponseAndReturnsResults)
+	ctx.Step(`^there is a Search API that gives a successful response with (\d+) provisional, (\d+) confirmed and (\d+) postponed releases$`, c.thereIsASearchAPIThatGivesASuccessfulResponseWithUpcomingReleases)
 	ctx.Step(`^there is a Release Calendar API that gives a successful response for "([^"]*)"$`, c.thereIsAReleaseAPIThatGivesASuccessfulResponseFor)
 	ctx.Step(`^there is a Release Calendar API that gives a successful response for "([^"]*)" with a migration link`, c.thereIsAReleaseAPIThatGivesASuccessfulResponseForWithMigrationLink)
 }
@@ -184,6 +185,15 @@ func (c *Component) thereIsASearchAPIThatGivesASuccessfulResponseAndReturnsResul
 	return nil
 }
 
+func (c *Component) thereIsASearchAPIThatGivesASuccessfulResponseWithUpcomingReleases(provisional, confirmed, postponed int) error {
+	c.FakeAPIRouter.searchReleasesRequest.Lock()
+	defer c.FakeAPIRouter.searchReleasesRequest.Unlock()
+
+	c.FakeAPIRouter.searchReleasesRequest.CustomHandle = upcomingReleasesHandle(provisional, confirmed, postponed)
+
+	return nil
+}
+
 func (c *Component) thereIsAReleaseAPIThatGivesASuccessfulResponseFor() error {
 	c.FakeAPIRouter.releaseRequest.Lock()
 	defer c.FakeAPIRouter.releaseRequest.Unlock()
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/ONSdigital/dp-frontend-release-calendar/api"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/mapper"
+	dphandlers "github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// APIReleaseCalendar will return a page of release calendar search results in the v1 JSON format
+func APIReleaseCalendar(cfg config.Config, searchAPI SearchAPI) http.HandlerFunc {
+	return dphandlers.ControllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, accessToken string) {
+		ctx := r.Context()
+
+		validatedParams, err := validateParams(ctx, r.URL.Query(), cfg)
+		if err != nil {
+			writeProblem(w, r, lang, err)
+			return
+		}
+
+		releases, err := getReleases(ctx, searchAPI, accessToken, collectionID, lang, validatedParams.AsBackendQuery())
+		if err != nil {
+			writeProblem(w, r, lang, err)
+			return
+		}
+
+		writeAPIResponse(w, r, lang, mapper.CreateAPIReleaseList(validatedParams, releases, cfg))
+	})
+}
+
+// APIRelease will return a single release in the v1 JSON format
+func APIRelease(cfg config.Config, rcAPI ReleaseCalendarAPI) http.HandlerFunc {
+	return dphandlers.ControllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, accessToken string) {
+		releaseURI := strings.TrimPrefix(r.URL.EscapedPath(), cfg.APIPath())
+
+		release, err := rcAPI.GetLegacyRelease(r.Context(), accessToken, collectionID, lang, releaseURI)
+		if err != nil {
+			writeProblem(w, r, lang, err)
+			return
+		}
+
+		writeAPIResponse(w, r, lang, mapper.CreateAPIRelease(*release, cfg))
+	})
+}
+
+// OpenAPISpec will return the OpenAPI document describing the JSON endpoints
+func OpenAPISpec() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(api.Spec); err != nil {
+			log.Error(r.Context(), "failed to write openapi spec", err)
+		}
+	}
+}
+
+func writeAPIResponse(w http.ResponseWriter, r *http.Request, lang string, body interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		writeProblem(w, r, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err = w.Write(data); err != nil {
+		log.Error(r.Context(), "failed to write api response", err)
+	}
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-design-system-go/v2/helper"
+	"github.com/ONSdigital/dp-api-clients-go/v2/releasecalendar"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	sitesearch "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/api"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/mocks"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAPIReleaseCalendar(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := gomock.Any()
+
+	Convey("Given the v1 release calendar endpoint", t, func() {
+		cfg, _ := config.Get()
+		mockSearchClient := NewMockSearchAPI(mockCtrl)
+		router := mux.NewRouter()
+		router.HandleFunc(cfg.APIPath()+"/releasecalendar", APIReleaseCalendar(*cfg, mockSearchClient))
+		w := httptest.NewRecorder()
+
+		Convey("When the search API returns releases", func() {
+			mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, gomock.Any()).Return(sitesearch.ReleaseResponse{
+				Breakdown: sitesearch.Breakdown{Total: 1, Published: 1},
+				Releases:  generateReleases(0, 1),
+			}, nil)
+
+			req := httptest.NewRequest("GET", "http://localhost:27700/v1/releasecalendar", http.NoBody)
+			router.ServeHTTP(w, req)
+
+			Convey("Then the releases are returned as JSON", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/json")
+
+				var list api.ReleaseList
+				So(json.Unmarshal(w.Body.Bytes(), &list), ShouldBeNil)
+				So(list.Count, ShouldEqual, 1)
+				So(list.Items, ShouldHaveLength, 1)
+				So(list.Items[0].URI, ShouldEqual, "/releases/release0")
+				So(list.Items[0].Links.Self.HRef, ShouldEqual, "/v1/releases/release0")
+				So(list.Items[0].Links.HTML.HRef, ShouldEqual, "/releases/release0")
+			})
+		})
+
+		Convey("When a query parameter is invalid", func() {
+			req := httptest.NewRequest("GET", "http://localhost:27700/v1/releasecalendar?limit=bad", http.NoBody)
+			router.ServeHTTP(w, req)
+
+			Convey("Then a 400 is returned describing the problem", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/problem+json")
+
+				var resp api.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &resp), ShouldBeNil)
+				So(resp.Status, ShouldEqual, http.StatusBadRequest)
+				So(resp.Errors, ShouldHaveLength, 1)
+				So(resp.Errors[0].Parameter, ShouldEqual, "limit")
+				So(resp.Errors[0].Code, ShouldEqual, "invalid_value")
+				So(resp.Errors[0].Detail, ShouldEqual, "Enter a number of results from 0 to 100")
+			})
+		})
+
+		Convey("When the search API returns an error", func() {
+			mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, gomock.Any()).
+				Return(sitesearch.ReleaseResponse{}, errors.New("search is down"))
+
+			req := httptest.NewRequest("GET", "http://localhost:27700/v1/releasecalendar", http.NoBody)
+			router.ServeHTTP(w, req)
+
+			Convey("Then a 500 is returned without the details of the error", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+
+				var resp api.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &resp), ShouldBeNil)
+				So(resp, ShouldResemble, api.Problem{Type: "about:blank", Title: "Internal Server Error", Status: http.StatusInternalServerError})
+			})
+		})
+	})
+}
+
+func TestAPIRelease(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := gomock.Any()
+
+	Convey("Given the v1 release endpoint", t, func() {
+		cfg, _ := config.Get()
+		mockAPIClient := NewMockReleaseCalendarAPI(mockCtrl)
+		router := mux.NewRouter()
+		router.HandleFunc(cfg.APIPath()+"/releases/{uri}", APIRelease(*cfg, mockAPIClient))
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "http://localhost:27700/v1/releases/my-release", http.NoBody)
+
+		Convey("When the release exists", func() {
+			release := releasecalendar.Release{
+				URI:         "/releases/my-release",
+				Description: releasecalendar.ReleaseDescription{Title: "My release", Published: true},
+			}
+			mockAPIClient.EXPECT().GetLegacyRelease(ctx, "", "", lang, "/releases/my-release").Return(&release, nil)
+			router.ServeHTTP(w, req)
+
+			Convey("Then the release is returned as JSON", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var resp api.Release
+				So(json.Unmarshal(w.Body.Bytes(), &resp), ShouldBeNil)
+				So(resp.Title, ShouldEqual, "My release")
+				So(resp.PublicationState.Type, ShouldEqual, "published")
+				So(resp.Links.Self.HRef, ShouldEqual, "/v1/releases/my-release")
+			})
+		})
+
+		Convey("When the release does not exist", func() {
+			mockAPIClient.EXPECT().GetLegacyRelease(ctx, "", "", lang, "/releases/my-release").Return(nil, &testCliError{})
+			router.ServeHTTP(w, req)
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+
+				var resp api.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &resp), ShouldBeNil)
+				So(resp, ShouldResemble, api.Problem{Type: "about:blank", Title: "Not Found", Status: http.StatusNotFound})
+			})
+		})
+	})
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	Convey("When the OpenAPI document is requested", t, func() {
+		w := httptest.NewRecorder()
+		OpenAPISpec()(w, httptest.NewRequest("GET", "http://localhost:27700/openapi.json", http.NoBody))
+
+		Convey("Then a valid JSON document is returned", func() {
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get("Content-Type"), ShouldEqual, "application/json")
+			So(json.Valid(w.Body.Bytes()), ShouldBeTrue)
+		})
+	})
+}
+
+type specParameter struct {
+	Name   string `json:"name"`
+	Schema struct {
+		Type    string   `json:"type"`
+		Enum    []string `json:"enum"`
+		Minimum *int     `json:"minimum"`
+		Maximum *int     `json:"maximum"`
+		Pattern string   `json:"pattern"`
+		MaxLen  *int     `json:"maxLength"`
+		Items   struct {
+			Enum []string `json:"enum"`
+		} `json:"items"`
+	} `json:"schema"`
+}
+
+// documentedValues returns query strings for a parameter that the OpenAPI document says are valid and invalid.
+// Day and month parameters are only valid alongside a year, so one is added.
+func documentedValues(p specParameter) (valid, invalid []url.Values) {
+	var values, undocumented []string
+	switch {
+	case len(p.Schema.Enum) > 0:
+		values = p.Schema.Enum
+		undocumented = []string{"undocumented"}
+	case p.Schema.Type == "array":
+		values = p.Schema.Items.Enum
+		undocumented = []string{"undocumented"}
+	case p.Schema.Type == "boolean":
+		values = []string{"true", "false"}
+		undocumented = []string{"maybe"}
+	case p.Schema.Type == "integer":
+		values = []string{strconv.Itoa(*p.Schema.Minimum), strconv.Itoa(*p.Schema.Maximum)}
+		undocumented = []string{"NaN", strconv.Itoa(*p.Schema.Minimum - 1), strconv.Itoa(*p.Schema.Maximum + 1)}
+	case p.Schema.MaxLen != nil:
+		values = []string{`"labour market" -scotland wages`, strings.Repeat("x", *p.Schema.MaxLen)}
+		undocumented = []string{strings.Repeat("x", *p.Schema.MaxLen+1)}
+	case p.Schema.Pattern != "":
+		// the only patterns documented are for dates, which must also exist
+		values = []string{"2020", "2020-02", "2020-02-29"}
+		undocumented = []string{"29/02/2020", "2020-2", "2020-02-30"}
+	default:
+		values = []string{"anything"}
+	}
+
+	query := func(value string) url.Values {
+		q := url.Values{p.Name: []string{value}}
+		for _, prefix := range []string{queryparams.After, queryparams.Before} {
+			if strings.HasPrefix(p.Name, prefix+"-") && p.Name != prefix+"-year" {
+				q.Set(prefix+"-year", "2020")
+			}
+		}
+		return q
+	}
+	for _, v := range values {
+		valid = append(valid, query(v))
+	}
+	for _, v := range undocumented {
+		invalid = append(invalid, query(v))
+	}
+	if p.Schema.Type == "array" {
+		valid = append(valid, url.Values{p.Name: values})
+	}
+	return valid, invalid
+}
+
+func TestReleaseCalendarDataMatchesSpec(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	Convey("Given the parameters documented for the release calendar data endpoint", t, func() {
+		var spec struct {
+			Components struct {
+				Parameters map[string]specParameter `json:"parameters"`
+			} `json:"components"`
+		}
+		So(json.Unmarshal(api.Spec, &spec), ShouldBeNil)
+
+		cfg, _ := config.Get()
+		mockSearchClient := NewMockSearchAPI(mockCtrl)
+		mockSearchClient.EXPECT().GetReleases(gomock.Any(), "", "", lang, gomock.Any()).Return(sitesearch.ReleaseResponse{}, nil).AnyTimes()
+		handler := ReleaseCalendarData(*cfg, mockSearchClient)
+
+		get := func(query url.Values) int {
+			w := httptest.NewRecorder()
+			handler(w, httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/data?"+query.Encode(), http.NoBody))
+			return w.Code
+		}
+
+		for _, p := range spec.Components.Parameters {
+			valid, invalid := documentedValues(p)
+
+			Convey(fmt.Sprintf("Then documented values of %q are accepted", p.Name), func() {
+				for _, q := range valid {
+					So(get(q), ShouldEqual, http.StatusOK)
+				}
+			})
+
+			if len(invalid) > 0 {
+				Convey(fmt.Sprintf("Then undocumented values of %q are rejected", p.Name), func() {
+					for _, q := range invalid {
+						So(get(q), ShouldEqual, http.StatusBadRequest)
+					}
+				})
+			}
+		}
+	})
+}
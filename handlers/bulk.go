@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	search "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/api"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/mapper"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	dphandlers "github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+// ReleaseCalendarBulk will stream every release matching the calendar filters as newline delimited JSON. Unlike the
+// calendar, the number of releases is not limited by DefaultMaximumSearchResults.
+func ReleaseCalendarBulk(cfg config.Config, searchAPI SearchAPI) http.HandlerFunc {
+	return dphandlers.ControllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, accessToken string) {
+		ctx := r.Context()
+		params := r.URL.Query()
+
+		// paging is handled by the export itself
+		params.Del(queryparams.Limit)
+		params.Del(queryparams.Page)
+
+		validatedParams, err := validateParams(ctx, params, cfg)
+		if err != nil {
+			writeProblem(w, r, lang, err)
+			return
+		}
+
+		// releases are streamed in date order so that the export can be resumed from any of them
+		if byDate, _ := dateOrder(validatedParams); !byDate {
+			validatedParams.Sort = queryparams.RelDateDesc
+		}
+
+		cursor, err := decodeReleaseCursor(params.Get(queryparams.Cursor), cfg.CursorSecret, validatedParams.Sort)
+		if err != nil {
+			writeProblem(w, r, lang, &validationErr{errs: []paramError{
+				newParamError(queryparams.Cursor, queryparams.ErrCodeInvalidValue, err, "ValidationCursor"),
+			}})
+			return
+		}
+
+		if err = streamReleases(ctx, w, validatedParams, cursor, cfg, accessToken, collectionID, lang, searchAPI); err != nil {
+			writeProblem(w, r, lang, err)
+			return
+		}
+	})
+}
+
+// streamReleases writes every release after cursor to the response, requesting them from the search API a page at
+// a time so that memory use does not depend on the number of releases. An error is only returned if nothing has
+// been written to the response yet. After that, a failure aborts the connection so that the client can tell the
+// export is incomplete and resume it from the last cursor it received.
+func streamReleases(ctx context.Context, w http.ResponseWriter, vp queryparams.ValidatedParams, cursor *releaseCursor, cfg config.Config,
+	accessToken, collectionID, lang string, searchAPI SearchAPI) error {
+	vp.Limit = cfg.DefaultMaximumLimit
+	vp.Offset = 0
+	vp.Page = queryparams.CalculatePageNumber(vp.Offset, vp.Limit)
+	vp.Highlight = false
+
+	enc := json.NewEncoder(w)
+	started := false
+	for {
+		if err := ctx.Err(); err != nil {
+			log.Info(ctx, "bulk export cancelled", log.Data{"cursor": cursor})
+			return nil
+		}
+
+		var releases search.ReleaseResponse
+		var next *releaseCursor
+		var err error
+		if cursor == nil {
+			releases, err = getReleases(ctx, searchAPI, accessToken, collectionID, lang, vp.AsBackendQuery())
+			next = nextCursor(vp, releases.Releases, cfg)
+		} else {
+			releases, next, err = getReleasesAfter(ctx, vp, *cursor, accessToken, collectionID, lang, cfg, searchAPI)
+		}
+		if err != nil {
+			if !started {
+				return err
+			}
+			log.Error(ctx, "failed to get page of releases for bulk export, aborting export", err, log.Data{"cursor": cursor})
+			panic(http.ErrAbortHandler)
+		}
+
+		if !started {
+			w.Header().Set("Content-Type", ndjsonContentType)
+			started = true
+		}
+
+		for i := range releases.Releases {
+			release := releases.Releases[i]
+			line := api.BulkRelease{
+				Cursor:  releaseCursor{Sort: vp.Sort.String(), ReleaseDate: release.Description.ReleaseDate, URI: release.URI}.encode(cfg.CursorSecret),
+				Release: mapper.CreateAPIReleaseSummary(release, cfg),
+			}
+			if err = enc.Encode(line); err != nil {
+				log.Warn(ctx, "failed to write release to bulk export", log.FormatErrors([]error{err}), log.Data{"uri": release.URI})
+				return nil
+			}
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+
+		if next == nil {
+			return nil
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	sitesearch "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/api"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func bulkLines(w *httptest.ResponseRecorder) []api.BulkRelease {
+	var lines []api.BulkRelease
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		var line api.BulkRelease
+		So(json.Unmarshal(scanner.Bytes(), &line), ShouldBeNil)
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// dailyReleases returns count releases a day apart, newest first
+func dailyReleases(count int) []sitesearch.Release {
+	releases := make([]sitesearch.Release, 0, count)
+	for i := 0; i < count; i++ {
+		releaseDate := time.Date(2026, 10, 25-i, 9, 30, 0, 0, time.UTC).Format(time.RFC3339)
+		releases = append(releases, datedRelease(fmt.Sprintf("/releases/release%d", i), releaseDate))
+	}
+	return releases
+}
+
+// searchReleases answers GetReleases from releases, which are newest first, applying the date-to, offset and limit
+// of the query as the Search API would. Every query is recorded in queries.
+func searchReleases(releases []sitesearch.Release, queries *[]url.Values) func(context.Context, string, string, string, url.Values) (sitesearch.ReleaseResponse, error) {
+	return func(_ context.Context, _, _, _ string, q url.Values) (sitesearch.ReleaseResponse, error) {
+		*queries = append(*queries, q)
+
+		matching := releases
+		if to := q.Get(queryparams.DateTo); to != "" {
+			end, _ := time.Parse("2006-01-02", to)
+			matching = nil
+			for _, r := range releases {
+				if released, _ := time.Parse(time.RFC3339, r.Description.ReleaseDate); released.Before(end.AddDate(0, 0, 1)) {
+					matching = append(matching, r)
+				}
+			}
+		}
+
+		offset, _ := strconv.Atoi(q.Get(queryparams.Offset))
+		limit, _ := strconv.Atoi(q.Get(queryparams.Limit))
+		response := sitesearch.ReleaseResponse{Breakdown: sitesearch.Breakdown{Total: len(matching)}}
+		if offset < len(matching) {
+			response.Releases = matching[offset:min(offset+limit, len(matching))]
+		}
+		return response, nil
+	}
+}
+
+func TestReleaseCalendarBulk(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := gomock.Any()
+
+	Convey("Given the bulk export endpoint", t, func() {
+		mockConfig, _ := config.Get()
+		cfg := *mockConfig
+		cfg.DefaultMaximumLimit = 2
+		cfg.DefaultMaximumSearchResults = 4
+
+		mockSearchClient := NewMockSearchAPI(mockCtrl)
+		handler := ReleaseCalendarBulk(cfg, mockSearchClient)
+		w := httptest.NewRecorder()
+		var queries []url.Values
+
+		Convey("When there are more releases than the calendar allows", func() {
+			mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, gomock.Any()).
+				DoAndReturn(searchReleases(dailyReleases(5), &queries)).AnyTimes()
+
+			handler(w, httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/bulk?limit=1&page=3", http.NoBody))
+
+			Convey("Then every release is streamed as a line of NDJSON", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/x-ndjson")
+
+				lines := bulkLines(w)
+				So(lines, ShouldHaveLength, 5)
+				for i := range lines {
+					So(lines[i].Release.URI, ShouldEqual, fmt.Sprintf("/releases/release%d", i))
+				}
+			})
+
+			Convey("Then each page is requested after the release date of the last, rather than at a deeper offset", func() {
+				So(queries[0], ShouldResemble, exportParams(0, 2))
+				for _, q := range queries {
+					So(q.Get(queryparams.Offset), ShouldBeIn, []string{"0", "2"})
+				}
+				So(queries[len(queries)-1].Get(queryparams.DateTo), ShouldEqual, "2026-10-23")
+			})
+
+			Convey("Then each cursor marks the release on its line", func() {
+				cursor, err := decodeReleaseCursor(bulkLines(w)[2].Cursor, cfg.CursorSecret, queryparams.RelDateDesc)
+				So(err, ShouldBeNil)
+				So(cursor.URI, ShouldEqual, "/releases/release2")
+				So(cursor.ReleaseDate, ShouldEqual, "2026-10-23T09:30:00Z")
+			})
+		})
+
+		Convey("When the export is resumed from a cursor after new releases have been published", func() {
+			releases := append([]sitesearch.Release{datedRelease("/releases/new", "2026-10-26T09:30:00Z")}, dailyReleases(5)...)
+			mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, gomock.Any()).
+				DoAndReturn(searchReleases(releases, &queries)).AnyTimes()
+
+			cursor := releaseCursor{Sort: queryparams.RelDateDesc.String(), ReleaseDate: "2026-10-23T09:30:00Z", URI: "/releases/release2"}
+			handler(w, httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/bulk?cursor="+url.QueryEscape(cursor.encode(cfg.CursorSecret)), http.NoBody))
+
+			Convey("Then only the releases after the cursor are streamed, without skipping or repeating any", func() {
+				lines := bulkLines(w)
+				So(lines, ShouldHaveLength, 2)
+				So(lines[0].Release.URI, ShouldEqual, "/releases/release3")
+				So(lines[1].Release.URI, ShouldEqual, "/releases/release4")
+			})
+		})
+
+		Convey("When the export is sorted by title", func() {
+			mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, gomock.Any()).
+				DoAndReturn(searchReleases(dailyReleases(1), &queries))
+
+			handler(w, httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/bulk?sort=alphabetical-az", http.NoBody))
+
+			Convey("Then the releases are streamed by release date instead", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(queries[0].Get(queryparams.SortName), ShouldEqual, queryparams.RelDateDesc.BackendString())
+			})
+		})
+
+		Convey("When the cursor is invalid", func() {
+			handler(w, httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/bulk?cursor=not-a-cursor", http.NoBody))
+
+			Convey("Then a 400 is returned for the cursor parameter", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+				var problem api.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+				So(problem.Errors, ShouldHaveLength, 1)
+				So(problem.Errors[0].Parameter, ShouldEqual, "cursor")
+			})
+		})
+
+		Convey("When the search API fails before anything is written", func() {
+			mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, exportParams(0, 2)).
+				Return(sitesearch.ReleaseResponse{}, errors.New("search is down"))
+
+			handler(w, httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/bulk", http.NoBody))
+
+			Convey("Then a 500 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+
+		Convey("When the search API fails part way through the export", func() {
+			gomock.InOrder(
+				mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, exportParams(0, 2)).
+					Return(sitesearch.ReleaseResponse{Breakdown: sitesearch.Breakdown{Total: 5}, Releases: dailyReleases(2)}, nil),
+				mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, gomock.Any()).
+					Return(sitesearch.ReleaseResponse{}, errors.New("search is down")),
+			)
+
+			var recovered interface{}
+			func() {
+				defer func() { recovered = recover() }()
+				handler(w, httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/bulk", http.NoBody))
+			}()
+
+			Convey("Then the connection is aborted after the releases already written", func() {
+				So(recovered, ShouldEqual, http.ErrAbortHandler)
+				So(bulkLines(w), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When the request is cancelled", func() {
+			reqCtx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			handler(w, httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/bulk", http.NoBody).WithContext(reqCtx))
+
+			Convey("Then the search API is not called", func() {
+				So(w.Body.Len(), ShouldEqual, 0)
+			})
+		})
+	})
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	search "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// releaseCursor marks the last release of a page of the calendar, so that the next page starts after it however the
+// releases have shifted since. Lists sorted by date are resumed from the release date and URI of the release; other
+// sort orders can only be resumed from an offset.
+type releaseCursor struct {
+	Sort        string `json:"s"`
+	ReleaseDate string `json:"d,omitempty"`
+	URI         string `json:"u,omitempty"`
+	Offset      int    `json:"o,omitempty"`
+}
+
+// encode returns the cursor as an opaque string, signed with secret so that it cannot be altered
+func (c releaseCursor) encode(secret string) string {
+	payload, _ := json.Marshal(c)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signCursor(encoded, secret))
+}
+
+// decodeReleaseCursor returns the cursor encoded by releaseCursor.encode, checking that it was signed with secret and
+// was made for a list in the same sort order. An empty cursor is returned as nil.
+func decodeReleaseCursor(s, secret string, sort queryparams.Sort) (*releaseCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	encoded, signature, ok := strings.Cut(s, ".")
+	if !ok {
+		return nil, errInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, signCursor(encoded, secret)) {
+		return nil, errInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	var c releaseCursor
+	if err = json.Unmarshal(payload, &c); err != nil || c.Offset < 0 {
+		return nil, errInvalidCursor
+	}
+	if c.Sort != sort.String() {
+		return nil, errors.New("cursor is for a different sort order")
+	}
+	if c.ReleaseDate != "" {
+		if _, err = time.Parse(time.RFC3339, c.ReleaseDate); err != nil {
+			return nil, errInvalidCursor
+		}
+	}
+
+	return &c, nil
+}
+
+func signCursor(encoded, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// dateOrder reports whether releases are listed by release date and, if so, whether the oldest come first
+func dateOrder(vp queryparams.ValidatedParams) (byDate, ascending bool) {
+	byDate = vp.Sort == queryparams.RelDateAsc || vp.Sort == queryparams.RelDateDesc
+	ascending = vp.AsBackendQuery().Get(queryparams.SortName) == queryparams.RelDateAsc.BackendString()
+	return byDate, ascending
+}
+
+// nextCursor returns the cursor for the page after releases, which were requested with vp, or nil if the page is the
+// last
+func nextCursor(vp queryparams.ValidatedParams, releases []search.Release, cfg config.Config) *releaseCursor {
+	if len(releases) == 0 || len(releases) < vp.Limit {
+		return nil
+	}
+
+	c := releaseCursor{Sort: vp.Sort.String()}
+	if byDate, _ := dateOrder(vp); byDate {
+		last := releases[len(releases)-1]
+		c.ReleaseDate, c.URI = last.Description.ReleaseDate, last.URI
+		return &c
+	}
+
+	c.Offset = vp.Offset + len(releases)
+	if c.Offset >= cfg.DefaultMaximumSearchResults {
+		return nil
+	}
+	return &c
+}
+
+// getReleasesAfter returns the page of releases that follows the cursor, and the cursor for the page after it. Lists
+// sorted by date are narrowed to the releases from the day of the cursor onwards, so that they are not limited by
+// DefaultMaximumSearchResults; the breakdown then only counts the releases in that window.
+func getReleasesAfter(ctx context.Context, vp queryparams.ValidatedParams, cursor releaseCursor, accessToken, collectionID, lang string,
+	cfg config.Config, api SearchAPI) (search.ReleaseResponse, *releaseCursor, error) {
+	byDate, ascending := dateOrder(vp)
+	if !byDate {
+		if cursor.Offset >= cfg.DefaultMaximumSearchResults {
+			return search.ReleaseResponse{}, nil, nil
+		}
+		vp.Offset = cursor.Offset
+		vp.Page = queryparams.CalculatePageNumber(vp.Offset, vp.Limit)
+		releases, err := getReleases(ctx, api, accessToken, collectionID, lang, vp.AsBackendQuery())
+		if err != nil {
+			return search.ReleaseResponse{}, nil, err
+		}
+		return releases, nextCursor(vp, releases.Releases, cfg), nil
+	}
+
+	// release dates are filtered by whole days, so the window starts a day beyond the cursor for it to be included
+	// whichever way the Search API treats the bounds, and the releases up to the cursor are skipped below
+	last, _ := time.Parse(time.RFC3339, cursor.ReleaseDate)
+	day := time.Date(last.UTC().Year(), last.UTC().Month(), last.UTC().Day(), 0, 0, 0, 0, time.UTC)
+	window := vp
+	if ascending {
+		if vp.AfterDate.String() == "" || day.After(vp.AfterDate.Time()) {
+			window.AfterDate = queryparams.DateFromTime(day)
+		}
+	} else {
+		if end := day.AddDate(0, 0, 1); vp.BeforeDate.String() == "" || end.Before(vp.BeforeDate.Time()) {
+			window.BeforeDate = queryparams.DateFromTime(end)
+		}
+	}
+	if window.AfterDate.String() != "" && window.BeforeDate.String() != "" && window.AfterDate.Time().After(window.BeforeDate.Time()) {
+		return search.ReleaseResponse{}, nil, nil
+	}
+	window.Limit = cfg.DefaultMaximumLimit
+
+	var response search.ReleaseResponse
+	page := make([]search.Release, 0, vp.Limit)
+	passed := false
+	for offset := 0; offset < cfg.DefaultMaximumSearchResults && len(page) < vp.Limit; offset += window.Limit {
+		window.Offset = offset
+		window.Page = queryparams.CalculatePageNumber(offset, window.Limit)
+
+		releases, err := getReleases(ctx, api, accessToken, collectionID, lang, window.AsBackendQuery())
+		if err != nil {
+			return search.ReleaseResponse{}, nil, err
+		}
+		if offset == 0 {
+			response = releases
+		}
+
+		for _, r := range releases.Releases {
+			if len(page) == vp.Limit {
+				break
+			}
+			released, err := time.Parse(time.RFC3339, r.Description.ReleaseDate)
+			switch {
+			case err != nil:
+				// a release that cannot be placed against the cursor is kept rather than lost
+			case released.Equal(last):
+				// releases at the same time as the cursor are listed before it until it is found
+				if !passed {
+					passed = r.URI == cursor.URI
+					continue
+				}
+			case released.Before(last) == ascending:
+				continue
+			}
+			page = append(page, r)
+		}
+
+		if len(releases.Releases) < window.Limit {
+			break
+		}
+	}
+
+	response.Releases = page
+	return response, nextCursor(vp, page, cfg), nil
+}
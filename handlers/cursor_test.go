@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	sitesearch "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func datedRelease(uri, releaseDate string) sitesearch.Release {
+	return sitesearch.Release{URI: uri, Description: sitesearch.ReleaseDescription{Title: uri, ReleaseDate: releaseDate, Published: true}}
+}
+
+func TestReleaseCursor(t *testing.T) {
+	Convey("Given a cursor encoded with a secret", t, func() {
+		cursor := releaseCursor{Sort: queryparams.RelDateDesc.String(), ReleaseDate: "2026-10-20T09:30:00Z", URI: "/releases/b"}
+		encoded := cursor.encode("secret")
+
+		Convey("Then it is decoded with the same secret and sort order", func() {
+			decoded, err := decodeReleaseCursor(encoded, "secret", queryparams.RelDateDesc)
+			So(err, ShouldBeNil)
+			So(*decoded, ShouldResemble, cursor)
+		})
+
+		Convey("Then it is rejected with another secret", func() {
+			_, err := decodeReleaseCursor(encoded, "another secret", queryparams.RelDateDesc)
+			So(err, ShouldEqual, errInvalidCursor)
+		})
+
+		Convey("Then it is rejected if it has been altered", func() {
+			altered := releaseCursor{Sort: queryparams.RelDateDesc.String(), Offset: 500}.encode("another secret")
+			_, err := decodeReleaseCursor(altered[:len(altered)/2]+encoded[len(encoded)/2:], "secret", queryparams.RelDateDesc)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Then it is rejected for another sort order", func() {
+			_, err := decodeReleaseCursor(encoded, "secret", queryparams.TitleAZ)
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given no cursor", t, func() {
+		decoded, err := decodeReleaseCursor("", "secret", queryparams.RelDateDesc)
+
+		Convey("Then nothing is returned", func() {
+			So(err, ShouldBeNil)
+			So(decoded, ShouldBeNil)
+		})
+	})
+}
+
+func TestReleaseCalendarDataCursor(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := gomock.Any()
+
+	Convey("Given the release calendar data endpoint", t, func() {
+		mockConfig, _ := config.Get()
+		cfg := *mockConfig
+		mockSearchClient := NewMockSearchAPI(mockCtrl)
+		handler := ReleaseCalendarData(cfg, mockSearchClient)
+		w := httptest.NewRecorder()
+
+		Convey("When a full page of releases is returned", func() {
+			mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, gomock.Any()).Return(sitesearch.ReleaseResponse{
+				Breakdown: sitesearch.Breakdown{Total: 5},
+				Releases:  []sitesearch.Release{datedRelease("/releases/a", "2026-10-21T09:30:00Z"), datedRelease("/releases/b", "2026-10-20T09:30:00Z")},
+			}, nil)
+
+			handler(w, httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/data?limit=2", http.NoBody))
+
+			Convey("Then the page has a cursor for the page after its last release", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var page releasesPage
+				So(json.Unmarshal(w.Body.Bytes(), &page), ShouldBeNil)
+				cursor, err := decodeReleaseCursor(page.NextCursor, cfg.CursorSecret, queryparams.RelDateDesc)
+				So(err, ShouldBeNil)
+				So(cursor.URI, ShouldEqual, "/releases/b")
+				So(cursor.ReleaseDate, ShouldEqual, "2026-10-20T09:30:00Z")
+			})
+		})
+
+		Convey("When the page after a cursor is requested", func() {
+			cursor := releaseCursor{Sort: queryparams.RelDateDesc.String(), ReleaseDate: "2026-10-20T09:30:00Z", URI: "/releases/b"}
+
+			var query url.Values
+			mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _, _, _ string, q url.Values) (sitesearch.ReleaseResponse, error) {
+					query = q
+					return sitesearch.ReleaseResponse{
+						Breakdown: sitesearch.Breakdown{Total: 6},
+						Releases: []sitesearch.Release{
+							datedRelease("/releases/z", "2026-10-21T07:00:00Z"),
+							datedRelease("/releases/a", "2026-10-20T09:30:00Z"),
+							datedRelease("/releases/b", "2026-10-20T09:30:00Z"),
+							datedRelease("/releases/c", "2026-10-20T09:30:00Z"),
+							datedRelease("/releases/d", "2026-10-19T09:30:00Z"),
+							datedRelease("/releases/e", "2026-10-18T09:30:00Z"),
+						},
+					}, nil
+				})
+
+			target := "http://localhost:27700/releasecalendar/data?limit=2&cursor=" + url.QueryEscape(cursor.encode(cfg.CursorSecret))
+			handler(w, httptest.NewRequest("GET", target, http.NoBody))
+
+			Convey("Then the releases up to the day after the cursor are requested, and those after it returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(query.Get(queryparams.DateTo), ShouldEqual, "2026-10-21")
+				So(query.Get(queryparams.Offset), ShouldEqual, "0")
+
+				var page releasesPage
+				So(json.Unmarshal(w.Body.Bytes(), &page), ShouldBeNil)
+				So(page.Releases, ShouldHaveLength, 2)
+				So(page.Releases[0].URI, ShouldEqual, "/releases/c")
+				So(page.Releases[1].URI, ShouldEqual, "/releases/d")
+				next, err := decodeReleaseCursor(page.NextCursor, cfg.CursorSecret, queryparams.RelDateDesc)
+				So(err, ShouldBeNil)
+				So(next.URI, ShouldEqual, "/releases/d")
+			})
+		})
+
+		Convey("When the cursor is invalid", func() {
+			handler(w, httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/data?cursor=abc.def", http.NoBody))
+
+			Convey("Then it returns 400", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+}
+
+func TestReleaseCalendarMore(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := gomock.Any()
+
+	Convey("Given the endpoint for the entries that follow a cursor", t, func() {
+		mockConfig, _ := config.Get()
+		cfg := *mockConfig
+		mockSearchClient := NewMockSearchAPI(mockCtrl)
+		mockRenderClient := NewMockRenderClient(mockCtrl)
+		handler := ReleaseCalendarMore(cfg, mockRenderClient, mockSearchClient)
+		w := httptest.NewRecorder()
+
+		Convey("When a cursor is given", func() {
+			cursor := releaseCursor{Sort: queryparams.RelDateDesc.String(), ReleaseDate: "2026-10-20T09:30:00Z", URI: "/releases/b"}
+			mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, gomock.Any()).Return(sitesearch.ReleaseResponse{
+				Breakdown: sitesearch.Breakdown{Total: 3},
+				Releases: []sitesearch.Release{
+					datedRelease("/releases/b", "2026-10-20T09:30:00Z"),
+					datedRelease("/releases/c", "2026-10-20T07:00:00Z"),
+				},
+			}, nil)
+			mockRenderClient.EXPECT().NewBasePageModel()
+
+			var page interface{}
+			mockRenderClient.EXPECT().BuildPage(w, gomock.Any(), "calendar-more").Do(func(_ io.Writer, p interface{}, _ string) {
+				page = p
+			})
+
+			target := "http://localhost:27700/releasecalendar/more?page=2&cursor=" + url.QueryEscape(cursor.encode(cfg.CursorSecret))
+			handler(w, httptest.NewRequest("GET", target, http.NoBody))
+
+			Convey("Then only the entries after the cursor are rendered, continuing its day", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				calendar, ok := page.(model.Calendar)
+				So(ok, ShouldBeTrue)
+				So(calendar.Entries.Items, ShouldHaveLength, 1)
+				So(calendar.Entries.Items[0].URI, ShouldEqual, "/releases/c")
+				So(calendar.Entries.Days, ShouldHaveLength, 1)
+				So(calendar.Entries.Days[0].Continued, ShouldBeTrue)
+				So(calendar.LoadMore, ShouldBeNil)
+			})
+		})
+
+		Convey("When no cursor is given", func() {
+			handler(w, httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/more", http.NoBody))
+
+			Convey("Then it returns 400", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+}
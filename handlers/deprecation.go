@@ -1,73 +1,131 @@
 package handlers
 
 import (
-	"errors"
+	"encoding/json"
+	"expvar"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/ONSdigital/dp-frontend-release-calendar/api"
 	"github.com/ONSdigital/dp-frontend-release-calendar/config"
 	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
 )
 
-// IsEndpointDeprecated takes a deprecation config and checks if an endpoint deprecation
-// flag is on. If on and deprecation date has passed; it sets the headers and returns a 404
-// with a message. It returns false if the deprecation date has not passed or there is an error.
-func IsEndpointDeprecated(w http.ResponseWriter, r *http.Request, deprecationConfig config.Deprecation) bool {
-	ctx := r.Context()
+// deprecatedRequests counts the requests to deprecated routes, keyed by the route and query parameter of the policy,
+// followed by whether the request was served or gone
+var deprecatedRequests = expvar.NewMap("deprecated_requests")
 
-	if deprecationConfig.DeprecateEndpoint {
-		now := time.Now().UTC()
+// Deprecations is the registry of the deprecation policies that are turned on, keyed by the path template of the
+// route without the routing prefix
+type Deprecations map[string][]config.RouteDeprecation
 
-		parsedDeprecation, err := parseTime(deprecationConfig.Deprecation)
-		if err != nil {
-			log.Error(ctx, "unable to parse deprecation date", err)
-			return false
+// NewDeprecations returns the registry of the policies that are turned on
+func NewDeprecations(policies []config.RouteDeprecation) Deprecations {
+	d := Deprecations{}
+	for _, p := range policies {
+		if p.DeprecateEndpoint {
+			d[p.Route] = append(d[p.Route], p)
 		}
-		deprecationUnix := fmt.Sprintf("@%d", parsedDeprecation.Unix())
+	}
+	return d
+}
 
-		parsedSunset, err := parseTime(deprecationConfig.Sunset)
-		if err != nil {
-			log.Error(ctx, "unable to parse sunset date", err)
-			return false
-		}
+// Middleware returns the middleware that applies the deprecation policy of the route that matched each request
+func (d Deprecations) Middleware(routingPrefix string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if policy, ok := d.policyFor(r, routingPrefix); ok && deprecate(w, r, policy) {
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
 
-		if parsedSunset.Before(now) {
-			w.Header().Set("content-type", "application/json")
+// policyFor returns the policy of the route that matched r. A policy for a query parameter that r has is chosen over
+// one for the whole route.
+func (d Deprecations) policyFor(r *http.Request, routingPrefix string) (config.RouteDeprecation, bool) {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return config.RouteDeprecation{}, false
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return config.RouteDeprecation{}, false
+	}
 
-			if deprecationConfig.Deprecation != "" {
-				w.Header().Set("Deprecation", deprecationUnix)
-			}
+	var policy config.RouteDeprecation
+	found := false
+	query := r.URL.Query()
+	for _, p := range d[strings.TrimPrefix(template, routingPrefix)] {
+		switch {
+		case p.QueryParam == "" && !found:
+			policy, found = p, true
+		case p.QueryParam != "" && query.Has(p.QueryParam):
+			return p, true
+		}
+	}
+	return policy, found
+}
 
-			if deprecationConfig.Sunset != "" {
-				w.Header().Set("Sunset", parsedSunset.Format(time.RFC1123))
-			}
+// deprecate announces the deprecation of a route on the response, as described by RFC 9745, and reports whether its
+// sunset has passed. Until the sunset the Deprecation, Sunset and Link headers are set and the route is still served;
+// after it a 410 Gone problem is written, with the deprecation message as its detail.
+func deprecate(w http.ResponseWriter, r *http.Request, policy config.RouteDeprecation) (gone bool) {
+	ctx := r.Context()
 
-			if deprecationConfig.Link != "" {
-				w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"sunset\"", deprecationConfig.Link))
-			}
+	if deprecation, err := config.ParseTime(policy.Deprecation.Deprecation); err == nil {
+		w.Header().Set("Deprecation", fmt.Sprintf("@%d", deprecation.Unix()))
+	} else {
+		log.Error(ctx, "unable to parse deprecation date", err, log.Data{"route": policy.String()})
+	}
 
-			w.WriteHeader(http.StatusNotFound)
+	sunset, err := config.ParseTime(policy.Sunset)
+	if err == nil {
+		w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+		gone = !time.Now().Before(sunset)
+	} else {
+		log.Error(ctx, "unable to parse sunset date", err, log.Data{"route": policy.String()})
+	}
 
-			if _, err = w.Write([]byte(deprecationConfig.DeprecationMessage)); err != nil {
-				log.Error(ctx, "unable to write deprecation message", err)
-				return false
-			}
-			return true
-		}
+	if policy.Link != "" {
+		w.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"deprecation\"; type=\"text/html\"", policy.Link))
+	}
+
+	if !gone {
+		deprecatedRequests.Add(policy.String()+" served", 1)
+		return false
+	}
+	deprecatedRequests.Add(policy.String()+" gone", 1)
 
+	data, err := json.Marshal(api.Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(http.StatusGone),
+		Status: http.StatusGone,
+		Detail: policy.DeprecationMessage,
+	})
+	if err != nil {
+		log.Error(ctx, "failed to marshal sunset problem details", err)
+		w.WriteHeader(http.StatusGone)
 		return true
 	}
 
-	return false
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(http.StatusGone)
+	if _, err = w.Write(data); err != nil {
+		log.Error(ctx, "unable to write deprecation message", err)
+	}
+	return true
 }
 
-// parseTime takes a time string and parses it to the time it represents.
-func parseTime(timeStr string) (time.Time, error) {
-	for _, timeFmt := range []string{time.RFC3339, time.DateOnly, time.DateTime} {
-		if parsedTime, err := time.Parse(timeFmt, timeStr); err == nil {
-			return parsedTime, nil
-		}
+// DeprecationMetrics returns the number of requests that each deprecation policy has served, or turned away as gone,
+// since the service started
+func DeprecationMetrics() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(deprecatedRequests.String()))
 	}
-	return time.Time{}, errors.New("invalid time format")
 }
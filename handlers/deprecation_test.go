@@ -2,14 +2,15 @@ package handlers
 
 import (
 	"encoding/json"
+	"expvar"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
-	"time"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/releasecalendar"
+	"github.com/ONSdigital/dp-frontend-release-calendar/api"
 	"github.com/ONSdigital/dp-frontend-release-calendar/config"
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
@@ -30,6 +31,7 @@ func TestEndpointDeprecation(t *testing.T) {
 
 	Convey("Test deprecation", t, func() {
 		mockConfig, _ := config.Get()
+		cfg := *mockConfig
 		mockAPIClient := NewMockReleaseCalendarAPI(mockCtrl)
 		root := "/releases"
 		w := httptest.NewRecorder()
@@ -45,53 +47,72 @@ func TestEndpointDeprecation(t *testing.T) {
 
 		Convey("Test '/releases/{release-title}/data' endpoint deprecation", func() {
 			Convey("When deprecation is enabled", func() {
-				mockConfig.Deprecation.DeprecateEndpoint = true
-				mockConfig.Deprecation.Link = sunsetLink
-				mockConfig.Deprecation.Deprecation = deprecationDate
-				mockConfig.Deprecation.DeprecationMessage = deprecationMessage
+				cfg.Deprecation.DeprecateEndpoint = true
+				cfg.Deprecation.Link = sunsetLink
+				cfg.Deprecation.Deprecation = deprecationDate
+				cfg.Deprecation.DeprecationMessage = deprecationMessage
 
 				Convey("And the sunset date has passed", func() {
-					mockConfig.Deprecation.Sunset = sunsetDate
+					cfg.Deprecation.Sunset = sunsetDate
 
 					Convey("And the release is retrieved successfully", func() {
 						req := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:27700%s/%s/data", root, titleSegment), http.NoBody)
 
-						router.HandleFunc(root+"/{release-title}/data", ReleaseData(*mockConfig, mockAPIClient))
+						router.Use(NewDeprecations(cfg.RouteDeprecations()).Middleware(cfg.RoutingPrefix))
+						router.HandleFunc(root+"/{uri}/data", ReleaseData(cfg, mockAPIClient))
 
 						router.ServeHTTP(w, req)
 
-						parsedSunset, _ := parseTime(sunsetDate)
-						parsedDeprecation, _ := parseTime(deprecationDate)
+						parsedSunset, _ := config.ParseTime(sunsetDate)
+						parsedDeprecation, _ := config.ParseTime(deprecationDate)
 
-						Convey("Then it returns 404", func() {
-							So(w.Code, ShouldEqual, http.StatusNotFound)
-							So(w.Header().Get("content-type"), ShouldEqual, "application/json")
+						Convey("Then it returns 410 with the deprecation message", func() {
+							So(w.Code, ShouldEqual, http.StatusGone)
+							So(w.Header().Get("content-type"), ShouldEqual, problemContentType)
 							So(w.Header().Get("deprecation"), ShouldEqual, fmt.Sprintf("@%d", parsedDeprecation.Unix()))
-							So(w.Header().Get("sunset"), ShouldEqual, parsedSunset.Format(time.RFC1123))
-							So(w.Header().Get("link"), ShouldEqual, fmt.Sprintf("<%s>; rel=\"sunset\"", sunsetLink))
+							So(w.Header().Get("sunset"), ShouldEqual, parsedSunset.UTC().Format(http.TimeFormat))
+							So(w.Header().Get("link"), ShouldEqual, fmt.Sprintf("<%s>; rel=\"deprecation\"; type=\"text/html\"", sunsetLink))
+
+							var problem api.Problem
+							So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+							So(problem.Status, ShouldEqual, http.StatusGone)
+							So(problem.Detail, ShouldEqual, deprecationMessage)
 						})
 					})
 				})
 
 				Convey("And the sunset date has not passed", func() {
-					mockConfig.Deprecation.Sunset = "2026-08-29"
+					cfg.Deprecation.Sunset = "2099-08-29"
 
 					Convey("And the release is retrieved successfully", func() {
+						mockAPIClient.EXPECT().GetLegacyRelease(ctx, accessToken, collectionID, lang, r.URI).Return(&r, nil)
+
 						req := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:27700%s/%s/data", root, titleSegment), http.NoBody)
+						if err := setRequestHeaders(req); err != nil {
+							t.Fatalf("unable to set request headers, error: %v", err)
+						}
 
-						router.HandleFunc(root+"/{release-title}/data", ReleaseData(*mockConfig, mockAPIClient))
+						router.Use(NewDeprecations(cfg.RouteDeprecations()).Middleware(cfg.RoutingPrefix))
+						router.HandleFunc(root+"/{uri}/data", ReleaseData(cfg, mockAPIClient))
 
 						router.ServeHTTP(w, req)
 
-						Convey("Then it returns 200", func() {
+						parsedDeprecation, _ := config.ParseTime(deprecationDate)
+						js, _ := json.Marshal(r)
+
+						Convey("Then the release is returned with the deprecation announced", func() {
 							So(w.Code, ShouldEqual, http.StatusOK)
+							So(w.Body.Bytes(), ShouldResemble, js)
+							So(w.Header().Get("deprecation"), ShouldEqual, fmt.Sprintf("@%d", parsedDeprecation.Unix()))
+							So(w.Header().Get("sunset"), ShouldEqual, "Sat, 29 Aug 2099 00:00:00 GMT")
+							So(w.Header().Get("link"), ShouldEqual, fmt.Sprintf("<%s>; rel=\"deprecation\"; type=\"text/html\"", sunsetLink))
 						})
 					})
 				})
 			})
 
 			Convey("When deprecation is disabled", func() {
-				mockConfig.Deprecation.DeprecateEndpoint = false
+				cfg.Deprecation.DeprecateEndpoint = false
 
 				js, _ := json.Marshal(r)
 				Convey("And the release is retrieved successfully", func() {
@@ -102,7 +123,8 @@ func TestEndpointDeprecation(t *testing.T) {
 						t.Fatalf("unable to set request headers, error: %v", err)
 					}
 
-					router.HandleFunc(root+"/{release-title}/data", ReleaseData(*mockConfig, mockAPIClient))
+					router.Use(NewDeprecations(cfg.RouteDeprecations()).Middleware(cfg.RoutingPrefix))
+					router.HandleFunc(root+"/{uri}/data", ReleaseData(cfg, mockAPIClient))
 
 					router.ServeHTTP(w, req)
 
@@ -116,3 +138,100 @@ func TestEndpointDeprecation(t *testing.T) {
 		})
 	})
 }
+
+func TestDeprecations(t *testing.T) {
+	Convey("Given deprecation policies for a route, a query parameter and a route that is not deprecated yet", t, func() {
+		sunset := config.Deprecation{DeprecateEndpoint: true, Deprecation: "2025-01-01", Sunset: "2025-06-01", DeprecationMessage: "gone"}
+		deprecations := NewDeprecations([]config.RouteDeprecation{
+			{Route: "/calendar/releasecalendar", Deprecation: sunset},
+			{Route: "/releasecalendar", QueryParam: "rss", Deprecation: sunset},
+			{Route: "/releasecalendar/data", Deprecation: config.Deprecation{DeprecateEndpoint: false, Deprecation: "2025-01-01", Sunset: "2025-06-01"}},
+		})
+
+		router := mux.NewRouter()
+		router.Use(deprecations.Middleware("/prefix"))
+		for _, path := range []string{"/prefix/calendar/releasecalendar", "/prefix/releasecalendar", "/prefix/releasecalendar/data"} {
+			router.Path(path).HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+		}
+
+		testcases := []struct {
+			target string
+			code   int
+		}{
+			{target: "/prefix/calendar/releasecalendar", code: http.StatusGone},
+			{target: "/prefix/releasecalendar?rss", code: http.StatusGone},
+			{target: "/prefix/releasecalendar?keywords=gdp", code: http.StatusOK},
+			{target: "/prefix/releasecalendar/data", code: http.StatusOK},
+		}
+		for _, tc := range testcases {
+			Convey("When "+tc.target+" is requested", func() {
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, httptest.NewRequest("GET", "http://localhost:27700"+tc.target, http.NoBody))
+
+				Convey(fmt.Sprintf("Then it returns %d", tc.code), func() {
+					So(w.Code, ShouldEqual, tc.code)
+				})
+			})
+		}
+	})
+
+	Convey("Given a route that is deprecated until a sunset in the future", t, func() {
+		deprecations := NewDeprecations([]config.RouteDeprecation{
+			{Route: "/releasecalendar/data", Deprecation: config.Deprecation{DeprecateEndpoint: true, Deprecation: "2025-01-01", Sunset: "2099-01-01"}},
+		})
+		router := mux.NewRouter()
+		router.Use(deprecations.Middleware(""))
+		router.Path("/releasecalendar/data").HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		Convey("When it is requested", func() {
+			var before int64
+			if count, ok := deprecatedRequests.Get("/releasecalendar/data served").(*expvar.Int); ok {
+				before = count.Value()
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/releasecalendar/data", http.NoBody))
+
+			Convey("Then it is served and counted", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Sunset"), ShouldEqual, "Thu, 01 Jan 2099 00:00:00 GMT")
+
+				var counts map[string]int64
+				metrics := httptest.NewRecorder()
+				DeprecationMetrics()(metrics, httptest.NewRequest("GET", "/metrics/deprecations", http.NoBody))
+				So(json.Unmarshal(metrics.Body.Bytes(), &counts), ShouldBeNil)
+				So(counts["/releasecalendar/data served"], ShouldEqual, before+1)
+			})
+		})
+	})
+
+	Convey("Given a policy for a route and another for one of its query parameters", t, func() {
+		deprecations := NewDeprecations([]config.RouteDeprecation{
+			{Route: "/releasecalendar", Deprecation: config.Deprecation{DeprecateEndpoint: true, Link: "https://www.ons.gov.uk/route"}},
+			{Route: "/releasecalendar", QueryParam: "rss", Deprecation: config.Deprecation{DeprecateEndpoint: true, Link: "https://www.ons.gov.uk/rss"}},
+		})
+		router := mux.NewRouter()
+		route := router.Path("/releasecalendar")
+
+		Convey("Then the policy for the query parameter is chosen when it is given", func() {
+			var policy config.RouteDeprecation
+			route.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				policy, _ = deprecations.policyFor(r, "")
+			})
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/releasecalendar?rss", http.NoBody))
+			So(policy.Link, ShouldEqual, "https://www.ons.gov.uk/rss")
+		})
+
+		Convey("Then the policy for the route is chosen otherwise", func() {
+			var policy config.RouteDeprecation
+			route.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				policy, _ = deprecations.policyFor(r, "")
+			})
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/releasecalendar?sort=alphabetical-az", http.NoBody))
+			So(policy.Link, ShouldEqual, "https://www.ons.gov.uk/route")
+		})
+	})
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	search "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/export"
+	"github.com/ONSdigital/dp-frontend-release-calendar/mapper"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	dphandlers "github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+	"golang.org/x/sync/errgroup"
+)
+
+// ReleaseCalendarExport will stream the releases matching the calendar filters as a spreadsheet
+func ReleaseCalendarExport(cfg config.Config, api SearchAPI, rcAPI ReleaseCalendarAPI) http.HandlerFunc {
+	return dphandlers.ControllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, accessToken string) {
+		ctx := r.Context()
+
+		format, err := export.ParseFormat(mux.Vars(r)["format"])
+		if err != nil {
+			writeProblem(w, r, lang, &clientErr{err})
+			return
+		}
+
+		validatedParams, err := validateParams(ctx, r.URL.Query(), cfg)
+		if err != nil {
+			writeProblem(w, r, lang, err)
+			return
+		}
+
+		if err = exportReleases(ctx, w, format, validatedParams, cfg, accessToken, collectionID, lang, api, rcAPI); err != nil {
+			writeProblem(w, r, lang, err)
+			return
+		}
+	})
+}
+
+// exportReleases pages through the search API, writing each page of releases to the response as it is received.
+// An error is only returned if nothing has been written to the response yet.
+func exportReleases(ctx context.Context, w http.ResponseWriter, format export.Format, vp queryparams.ValidatedParams, cfg config.Config,
+	accessToken, collectionID, lang string, api SearchAPI, rcAPI ReleaseCalendarAPI) error {
+	pageSize := cfg.DefaultMaximumLimit
+	vp.Limit = pageSize
+	vp.Highlight = false
+
+	// the releases are read through in turn, so that each page of each release type is only requested once
+	vp.Offset, vp.Page = 0, 1
+	pages := newReleaseMerger(vp.AsBackendQuery(), pageSize)
+
+	var ew export.Writer
+	for offset := 0; offset < cfg.DefaultMaximumSearchResults; offset += pageSize {
+		releases, err := pages.next(ctx, api, accessToken, collectionID, lang, pageSize)
+		if err != nil {
+			if ew == nil {
+				return err
+			}
+			log.Error(ctx, "failed to get page of releases for export, export truncated", err, log.Data{"offset": offset})
+			break
+		}
+
+		if ew == nil {
+			w.Header().Set("Content-Type", format.ContentType)
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", format.Filename()))
+			if ew, err = format.NewWriter(w); err != nil {
+				return err
+			}
+		}
+
+		cancellationNotices := getCancellationNotices(ctx, accessToken, collectionID, lang, releases, rcAPI)
+		for i := range releases {
+			if err = ew.Write(mapper.CreateExportRow(releases[i], cancellationNotices[releases[i].URI], cfg.RoutingPrefix)); err != nil {
+				log.Error(ctx, "failed to write release to export", err)
+				return nil
+			}
+		}
+
+		if err = ew.Flush(); err != nil {
+			log.Error(ctx, "failed to flush export", err)
+			return nil
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+
+		if len(releases) < pageSize || pages.done() {
+			break
+		}
+	}
+
+	if ew == nil {
+		return nil
+	}
+
+	if err := ew.Close(); err != nil {
+		log.Error(ctx, "failed to close export", err)
+	}
+
+	return nil
+}
+
+// cancellationNoticeLookups is the number of releases requested from the release calendar API at once for their
+// cancellation notices
+const cancellationNoticeLookups = 10
+
+// getCancellationNotices returns the cancellation notices of the cancelled releases in a page of search results, keyed
+// by URI. They are not included in search results, so are requested from the release calendar API together for the
+// whole page before it is written, rather than one at a time as each row is written.
+func getCancellationNotices(ctx context.Context, accessToken, collectionID, lang string, releases []search.Release, rcAPI ReleaseCalendarAPI) map[string][]string {
+	var (
+		mu      sync.Mutex
+		notices = make(map[string][]string)
+		g       errgroup.Group
+	)
+	g.SetLimit(cancellationNoticeLookups)
+
+	for i := range releases {
+		if !releases[i].Description.Cancelled {
+			continue
+		}
+		uri := releases[i].URI
+		g.Go(func() error {
+			release, err := rcAPI.GetLegacyRelease(ctx, accessToken, collectionID, lang, uri)
+			if err != nil {
+				log.Warn(ctx, "unable to get cancellation notice for export", log.FormatErrors([]error{err}), log.Data{"uri": uri})
+				return nil
+			}
+
+			mu.Lock()
+			notices[uri] = release.Description.CancellationNotice
+			mu.Unlock()
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return notices
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/releasecalendar"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	sitesearch "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func exportParams(offset, limit int) url.Values {
+	values := url.Values{}
+	values.Set("limit", strconv.Itoa(limit))
+	values.Set("page", strconv.Itoa(queryparams.CalculatePageNumber(offset, limit)))
+	values.Set("offset", strconv.Itoa(offset))
+	values.Set("sort", queryparams.RelDateDesc.BackendString())
+	values.Set("release-type", queryparams.Published.Name())
+	return values
+}
+
+func generateReleases(from, count int) []sitesearch.Release {
+	releases := make([]sitesearch.Release, 0, count)
+	for i := from; i < from+count; i++ {
+		releases = append(releases, sitesearch.Release{
+			URI:         fmt.Sprintf("/releases/release%d", i),
+			Description: sitesearch.ReleaseDescription{Title: fmt.Sprintf("Release %d", i), Published: true},
+		})
+	}
+	return releases
+}
+
+func TestReleaseCalendarExport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := gomock.Any()
+
+	Convey("Given the release calendar export endpoint", t, func() {
+		mockConfig, _ := config.Get()
+		cfg := *mockConfig
+		cfg.DefaultMaximumLimit = 2
+		cfg.DefaultMaximumSearchResults = 6
+
+		mockSearchClient := NewMockSearchAPI(mockCtrl)
+		mockAPIClient := NewMockReleaseCalendarAPI(mockCtrl)
+		router := mux.NewRouter()
+		router.HandleFunc("/releasecalendar/export.{format}", ReleaseCalendarExport(cfg, mockSearchClient, mockAPIClient))
+		w := httptest.NewRecorder()
+
+		Convey("When a CSV export spans several pages of search results", func() {
+			gomock.InOrder(
+				mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, exportParams(0, 2)).
+					Return(sitesearch.ReleaseResponse{Breakdown: sitesearch.Breakdown{Total: 5}, Releases: generateReleases(0, 2)}, nil),
+				mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, exportParams(2, 2)).
+					Return(sitesearch.ReleaseResponse{Breakdown: sitesearch.Breakdown{Total: 5}, Releases: generateReleases(2, 2)}, nil),
+				mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, exportParams(4, 2)).
+					Return(sitesearch.ReleaseResponse{Breakdown: sitesearch.Breakdown{Total: 5}, Releases: generateReleases(4, 1)}, nil),
+			)
+
+			req := httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/export.csv", http.NoBody)
+			router.ServeHTTP(w, req)
+
+			Convey("Then every page is written as a CSV attachment", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "text/csv; charset=utf-8")
+				So(w.Header().Get("Content-Disposition"), ShouldEqual, "attachment; filename=releases.csv")
+
+				records, err := csv.NewReader(w.Body).ReadAll()
+				So(err, ShouldBeNil)
+				So(records, ShouldHaveLength, 6)
+				So(records[1][0], ShouldEqual, "Release 0")
+				So(records[5][0], ShouldEqual, "Release 4")
+				So(records[5][4], ShouldEqual, "published")
+			})
+		})
+
+		Convey("When the export is of several release types", func() {
+			byType := map[string][]sitesearch.Release{
+				queryparams.Published.Name(): {
+					datedRelease("/releases/p1", "2026-10-25T09:30:00Z"),
+					datedRelease("/releases/p2", "2026-10-23T09:30:00Z"),
+					datedRelease("/releases/p3", "2026-10-21T09:30:00Z"),
+				},
+				queryparams.Cancelled.Name(): {
+					datedRelease("/releases/c1", "2026-10-24T09:30:00Z"),
+					datedRelease("/releases/c2", "2026-10-22T09:30:00Z"),
+				},
+			}
+			var queries []url.Values
+			mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, gomock.Any()).DoAndReturn(
+				func(c context.Context, accessToken, collectionID, lang string, q url.Values) (sitesearch.ReleaseResponse, error) {
+					return searchReleases(byType[q.Get(queryparams.Type)], &queries)(c, accessToken, collectionID, lang, q)
+				}).AnyTimes()
+
+			req := httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/export.csv?release-type=type-published&release-type=type-cancelled", http.NoBody)
+			router.ServeHTTP(w, req)
+
+			Convey("Then each page of each release type is requested once", func() {
+				So(queries, ShouldHaveLength, 3)
+			})
+
+			Convey("Then the releases of both types are written in date order", func() {
+				records, err := csv.NewReader(w.Body).ReadAll()
+				So(err, ShouldBeNil)
+				So(records, ShouldHaveLength, 6)
+				So(records[1][0], ShouldEqual, "/releases/p1")
+				So(records[2][0], ShouldEqual, "/releases/c1")
+				So(records[5][0], ShouldEqual, "/releases/p3")
+			})
+		})
+
+		Convey("When the export contains cancelled releases", func() {
+			cancelled := []sitesearch.Release{
+				{URI: "/releases/cancelled", Description: sitesearch.ReleaseDescription{Title: "Cancelled release", Cancelled: true}},
+				{URI: "/releases/withdrawn", Description: sitesearch.ReleaseDescription{Title: "Withdrawn release", Cancelled: true}},
+			}
+			mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, exportParams(0, 2)).
+				Return(sitesearch.ReleaseResponse{Breakdown: sitesearch.Breakdown{Total: 2}, Releases: cancelled}, nil)
+			mockAPIClient.EXPECT().GetLegacyRelease(ctx, "", "", lang, cancelled[0].URI).
+				Return(&releasecalendar.Release{Description: releasecalendar.ReleaseDescription{CancellationNotice: []string{"No longer produced"}}}, nil)
+			mockAPIClient.EXPECT().GetLegacyRelease(ctx, "", "", lang, cancelled[1].URI).
+				Return(nil, errors.New("release not found"))
+
+			req := httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/export.csv", http.NoBody)
+			router.ServeHTTP(w, req)
+
+			Convey("Then the cancellation notices are retrieved from the release calendar API", func() {
+				records, err := csv.NewReader(w.Body).ReadAll()
+				So(err, ShouldBeNil)
+				So(records, ShouldHaveLength, 3)
+				So(records[1][7], ShouldEqual, "No longer produced")
+				So(records[2][7], ShouldEqual, "")
+			})
+		})
+
+		Convey("When an XLSX export is requested", func() {
+			mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, exportParams(0, 2)).
+				Return(sitesearch.ReleaseResponse{Breakdown: sitesearch.Breakdown{Total: 1}, Releases: generateReleases(0, 1)}, nil)
+
+			req := httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/export.xlsx", http.NoBody)
+			router.ServeHTTP(w, req)
+
+			Convey("Then a workbook attachment is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+				So(w.Header().Get("Content-Disposition"), ShouldEqual, "attachment; filename=releases.xlsx")
+				So(w.Body.Bytes()[:2], ShouldResemble, []byte("PK"))
+			})
+		})
+
+		Convey("When an unsupported format is requested", func() {
+			req := httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/export.pdf", http.NoBody)
+			router.ServeHTTP(w, req)
+
+			Convey("Then it returns 400", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When the first page of search results cannot be retrieved", func() {
+			mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, exportParams(0, 2)).
+				Return(sitesearch.ReleaseResponse{}, errors.New("error reading data"))
+
+			req := httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/export.csv", http.NoBody)
+			router.ServeHTTP(w, req)
+
+			Convey("Then it returns 500", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/featureflags"
+	"github.com/gorilla/mux"
+)
+
+// visitorCookieAge is how long a visitor keeps the same flags
+const visitorCookieAge = 365 * 24 * time.Hour
+
+// FeatureFlags returns the middleware that evaluates the feature flags of cfg for the visitor making each request,
+// and adds them to the context of the request. A visitor is given an ID cookie when a flag is rolled out to only some
+// visitors. Overrides given in the query replace those in the cookie, and are kept in it so that testers keep them as
+// they move around the site.
+func FeatureFlags(cfg config.Config) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var visitorID string
+			if featureflags.NeedsVisitor(cfg.FeatureFlags) {
+				if cookie, err := r.Cookie(featureflags.VisitorCookie); err == nil && cookie.Value != "" {
+					visitorID = cookie.Value
+				} else {
+					visitorID = featureflags.NewVisitorID()
+					setFeatureCookie(w, featureflags.VisitorCookie, visitorID, visitorCookieAge)
+				}
+			}
+
+			var override string
+			if cookie, err := r.Cookie(featureflags.OverrideCookie); err == nil {
+				override = cookie.Value
+			}
+			if query := r.URL.Query(); query.Has(featureflags.OverrideParam) {
+				override = query.Get(featureflags.OverrideParam)
+				if override == "" {
+					setFeatureCookie(w, featureflags.OverrideCookie, "", -1)
+				} else {
+					setFeatureCookie(w, featureflags.OverrideCookie, override, 0)
+				}
+			}
+
+			flags := featureflags.Evaluate(cfg.FeatureFlags, visitorID, override)
+			next.ServeHTTP(w, featureflags.Pin(r, flags))
+		})
+	}
+}
+
+// setFeatureCookie sets a cookie for the site, which lasts for the session if maxAge is 0 and is removed if it is
+// negative
+func setFeatureCookie(w http.ResponseWriter, name, value string, maxAge time.Duration) {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	switch {
+	case maxAge < 0:
+		cookie.MaxAge = -1
+	case maxAge > 0:
+		cookie.MaxAge = int(maxAge.Seconds())
+	}
+	http.SetCookie(w, cookie)
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	sitesearch "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/featureflags"
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFeatureFlags(t *testing.T) {
+	Convey("Given the feature flags middleware", t, func() {
+		var flags featureflags.Flags
+		next := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			flags = featureflags.FromContext(r.Context())
+		})
+		w := httptest.NewRecorder()
+
+		Convey("When a flag is rolled out to some visitors and the visitor is new", func() {
+			handler := FeatureFlags(config.Config{FeatureFlags: map[string]int{"grouping": 50}})(next)
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/releasecalendar", http.NoBody))
+
+			Convey("Then the flags are evaluated and the visitor is given an ID", func() {
+				So(flags, ShouldContainKey, "grouping")
+				cookies := w.Result().Cookies()
+				So(cookies, ShouldHaveLength, 1)
+				So(cookies[0].Name, ShouldEqual, featureflags.VisitorCookie)
+				So(cookies[0].Value, ShouldNotBeEmpty)
+
+				Convey("And the flags are the same when the visitor returns", func() {
+					r := httptest.NewRequest(http.MethodGet, "/releasecalendar", http.NoBody)
+					r.AddCookie(cookies[0])
+					returned := httptest.NewRecorder()
+					first := flags
+					handler.ServeHTTP(returned, r)
+					So(flags, ShouldResemble, first)
+					So(returned.Result().Cookies(), ShouldBeEmpty)
+				})
+			})
+		})
+
+		Convey("When every flag is on or off for everybody", func() {
+			FeatureFlags(config.Config{FeatureFlags: map[string]int{"grouping": 100}})(next).
+				ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/releasecalendar", http.NoBody))
+
+			Convey("Then the visitor is not given an ID", func() {
+				So(flags.Enabled("grouping"), ShouldBeTrue)
+				So(w.Result().Cookies(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a tester overrides a flag in the query", func() {
+			FeatureFlags(config.Config{FeatureFlags: map[string]int{"grouping": 0}})(next).
+				ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/releasecalendar?features=grouping", http.NoBody))
+
+			Convey("Then the flag is on and the override is kept in a cookie", func() {
+				So(flags.Enabled("grouping"), ShouldBeTrue)
+				cookies := w.Result().Cookies()
+				So(cookies, ShouldHaveLength, 1)
+				So(cookies[0].Name, ShouldEqual, featureflags.OverrideCookie)
+				So(cookies[0].Value, ShouldEqual, "grouping")
+			})
+		})
+
+		Convey("When a tester has an override cookie", func() {
+			r := httptest.NewRequest(http.MethodGet, "/releasecalendar", http.NoBody)
+			r.AddCookie(&http.Cookie{Name: featureflags.OverrideCookie, Value: "-grouping"})
+			FeatureFlags(config.Config{FeatureFlags: map[string]int{"grouping": 100}})(next).ServeHTTP(w, r)
+
+			Convey("Then the flag is overridden", func() {
+				So(flags.Enabled("grouping"), ShouldBeFalse)
+			})
+		})
+
+		Convey("When a tester clears the overrides in the query", func() {
+			r := httptest.NewRequest(http.MethodGet, "/releasecalendar?features=", http.NoBody)
+			r.AddCookie(&http.Cookie{Name: featureflags.OverrideCookie, Value: "-grouping"})
+			FeatureFlags(config.Config{FeatureFlags: map[string]int{"grouping": 100}})(next).ServeHTTP(w, r)
+
+			Convey("Then the flag takes its rollout and the cookie is removed", func() {
+				So(flags.Enabled("grouping"), ShouldBeTrue)
+				cookies := w.Result().Cookies()
+				So(cookies, ShouldHaveLength, 1)
+				So(cookies[0].MaxAge, ShouldBeLessThan, 0)
+			})
+		})
+	})
+}
+
+func TestPinnedFeatureFlags(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	Convey("Given a request with feature flags pinned", t, func() {
+		mockConfig, _ := config.Get()
+		cfg := *mockConfig
+		mockSearchClient := NewMockSearchAPI(mockCtrl)
+		mockRenderClient := NewMockRenderClient(mockCtrl)
+		w := httptest.NewRecorder()
+
+		cursor := releaseCursor{Sort: queryparams.RelDateDesc.String(), ReleaseDate: "2026-10-20T09:30:00Z", URI: "/releases/b"}
+		target := "http://localhost:27700/releasecalendar/more?cursor=" + url.QueryEscape(cursor.encode(cfg.CursorSecret))
+		r := featureflags.Pin(httptest.NewRequest("GET", target, http.NoBody), featureflags.Flags{"grouping": true, "topic-filter": false})
+
+		mockSearchClient.EXPECT().GetReleases(gomock.Any(), "", "", lang, gomock.Any()).Return(sitesearch.ReleaseResponse{}, nil)
+		mockRenderClient.EXPECT().NewBasePageModel()
+		var page interface{}
+		mockRenderClient.EXPECT().BuildPage(w, gomock.Any(), "calendar-more").Do(func(_ io.Writer, p interface{}, _ string) {
+			page = p
+		})
+
+		Convey("When the page is rendered", func() {
+			ReleaseCalendarMore(cfg, mockRenderClient, mockSearchClient)(w, r)
+
+			Convey("Then the flags are given to the template", func() {
+				calendar, ok := page.(model.Calendar)
+				So(ok, ShouldBeTrue)
+				So(calendar.Features.Enabled("grouping"), ShouldBeTrue)
+				So(calendar.Features.Enabled("topic-filter"), ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	search "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/featureflags"
+	"github.com/ONSdigital/dp-frontend-release-calendar/mapper"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+)
+
+// releaseCalendarGrid renders the calendar page showing the releases in a month or week as a grid
+func releaseCalendarGrid(w http.ResponseWriter, r *http.Request, vp queryparams.ValidatedParams, accessToken, collectionID, lang string,
+	homepageContent zebedee.HomepageContent, cfg config.Config, rc RenderClient, api SearchAPI, now time.Time) {
+	// the canonical URL keeps a grid without a date on the current month or week
+	canonical := canonicalURL(cfg, vp)
+	if vp.ViewDate.String() == "" {
+		vp.ViewDate = queryparams.LocalDate(now)
+	}
+	// the grid shows the whole month or week on one page
+	vp.Page = 1
+	vp.Offset = 0
+
+	releases, err := getGridReleases(r.Context(), vp, accessToken, collectionID, lang, cfg, api)
+	if err != nil {
+		setStatusCode(r, w, err)
+		return
+	}
+
+	calendar := mapper.CreateReleaseCalendar(rc.NewBasePageModel(), vp, releases, cfg, lang, homepageContent.ServiceMessage, homepageContent.EmergencyBanner, nil)
+	calendar.Features = featureflags.FromContext(r.Context())
+	calendar.Grid = mapper.CreateCalendarGrid(vp, calendar.Entries.Items, cfg.CalendarPath(), lang, now)
+	setCanonicalURL(w, cfg, &calendar, canonical)
+	rc.BuildPage(w, calendar, "calendar")
+}
+
+// getGridReleases returns the releases matching the filters in the month or week of the grid, requesting them from
+// the search API a page at a time. Any date filters narrow the window further.
+func getGridReleases(ctx context.Context, vp queryparams.ValidatedParams, accessToken, collectionID, lang string, cfg config.Config, api SearchAPI) (search.ReleaseResponse, error) {
+	start, end := vp.View.Window(vp.ViewDate.Time())
+	if vp.AfterDate.String() == "" || vp.AfterDate.Time().Before(start) {
+		vp.AfterDate = queryparams.DateFromTime(start)
+	}
+	if vp.BeforeDate.String() == "" || vp.BeforeDate.Time().After(end) {
+		vp.BeforeDate = queryparams.DateFromTime(end)
+	}
+	if vp.AfterDate.Time().After(vp.BeforeDate.Time()) {
+		return search.ReleaseResponse{}, nil
+	}
+
+	vp.Limit = cfg.DefaultMaximumLimit
+	vp.Highlight = false
+
+	var response search.ReleaseResponse
+	for offset := 0; offset < cfg.DefaultMaximumSearchResults; offset += vp.Limit {
+		vp.Offset = offset
+		vp.Page = queryparams.CalculatePageNumber(offset, vp.Limit)
+
+		releases, err := getReleases(ctx, api, accessToken, collectionID, lang, vp.AsBackendQuery())
+		if err != nil {
+			return search.ReleaseResponse{}, err
+		}
+
+		if offset == 0 {
+			response = releases
+		} else {
+			response.Releases = append(response.Releases, releases.Releases...)
+		}
+
+		if len(releases.Releases) == 0 || len(releases.Releases) < vp.Limit || offset+vp.Limit >= releases.Breakdown.Total {
+			break
+		}
+	}
+
+	return response, nil
+}
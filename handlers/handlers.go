@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,8 +16,11 @@ import (
 	core "github.com/ONSdigital/dis-design-system-go/v2/model"
 	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
 	search "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/featureflags"
 	"github.com/ONSdigital/dp-frontend-release-calendar/mapper"
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
 	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
 	"github.com/gorilla/feeds"
 
@@ -31,6 +35,9 @@ const (
 	homepagePath   = "/"
 )
 
+// defaultReleaseTypes are the release types shown when none are requested
+var defaultReleaseTypes = queryparams.NewReleaseTypes(queryparams.Published)
+
 func setStatusCode(req *http.Request, w http.ResponseWriter, err error) {
 	status := http.StatusInternalServerError
 	if clientErr, ok := err.(ClientError); ok {
@@ -67,6 +74,7 @@ func Release(cfg config.Config, rc RenderClient, api ReleaseCalendarAPI, zc Zebe
 
 		basePage := rc.NewBasePageModel()
 		m := mapper.CreateRelease(cfg, basePage, *release, lang, cfg.CalendarPath(), homepageContent.ServiceMessage, homepageContent.EmergencyBanner)
+		m.Features = featureflags.FromContext(ctx)
 
 		b, err := json.Marshal(m)
 		if err != nil {
@@ -87,25 +95,21 @@ func Release(cfg config.Config, rc RenderClient, api ReleaseCalendarAPI, zc Zebe
 
 func ReleaseData(cfg config.Config, api ReleaseCalendarAPI) http.HandlerFunc {
 	return dphandlers.ControllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, accessToken string) {
-		if deprecated := IsEndpointDeprecated(w, r, cfg.Deprecation); deprecated {
-			return
-		}
-
 		release, err := api.GetLegacyRelease(r.Context(), accessToken, collectionID, lang, strings.TrimSuffix(strings.TrimPrefix(r.URL.EscapedPath(), cfg.RoutingPrefix), "/data"))
 		if err != nil {
-			setStatusCode(r, w, err)
+			writeProblem(w, r, lang, err)
 			return
 		}
 
 		data, err := json.Marshal(release)
 		if err != nil {
-			setStatusCode(r, w, err)
+			writeProblem(w, r, lang, err)
 			return
 		}
 
 		w.Header().Set("content-type", "application/json")
 		if _, err = w.Write(data); err != nil {
-			setStatusCode(r, w, err)
+			writeProblem(w, r, lang, err)
 			return
 		}
 	})
@@ -124,7 +128,9 @@ func ReleaseCalendar(cfg config.Config, rc RenderClient, api SearchAPI, zc Zebed
 
 		validatedParams, validationErrs := validateParamsAsFrontend(ctx, params, cfg)
 		if len(validationErrs) > 0 {
-			calendar := mapper.CreateReleaseCalendar(rc.NewBasePageModel(), validatedParams, search.ReleaseResponse{}, cfg, lang, homepageContent.ServiceMessage, homepageContent.EmergencyBanner, validationErrs)
+			calendar := mapper.CreateReleaseCalendar(rc.NewBasePageModel(), validatedParams, search.ReleaseResponse{}, cfg, lang, homepageContent.ServiceMessage, homepageContent.EmergencyBanner, errorItems(validationErrs))
+			calendar.Features = featureflags.FromContext(ctx)
+			setCanonicalURL(w, cfg, &calendar, canonicalURL(cfg, validatedParams))
 			rc.BuildPage(w, calendar, "calendar")
 			return
 		}
@@ -138,201 +144,335 @@ func ReleaseCalendar(cfg config.Config, rc RenderClient, api SearchAPI, zc Zebed
 			return
 		}
 
-		releases, err := api.GetReleases(ctx, accessToken, collectionID, lang, validatedParams.AsBackendQuery())
+		// every search has a single URL, so a query written differently, even if only in the order of its parameters,
+		// is redirected to it
+		canonical := validatedParams.CanonicalQuery(canonicalDefaults(cfg))
+		if r.Method == http.MethodGet && canonical.Encode() != r.URL.RawQuery {
+			//nolint:gosec // G710: the redirect is to the calendar path with a query rebuilt from validated parameters
+			http.Redirect(w, r, canonicalURL(cfg, validatedParams), http.StatusMovedPermanently)
+			return
+		}
+
+		if validatedParams.View.IsGrid() {
+			releaseCalendarGrid(w, r, validatedParams, accessToken, collectionID, lang, homepageContent, cfg, rc, api, cfg.Now())
+			return
+		}
+
+		releases, previousReleaseDate, err := getListReleases(ctx, validatedParams, accessToken, collectionID, lang, api)
 		if err != nil {
 			setStatusCode(r, w, err)
 			return
 		}
 
 		calendar := mapper.CreateReleaseCalendar(rc.NewBasePageModel(), validatedParams, releases, cfg, lang, homepageContent.ServiceMessage, homepageContent.EmergencyBanner, nil)
+		calendar.Features = featureflags.FromContext(ctx)
+		mapper.MarkContinuedDay(calendar.Entries.Days, previousReleaseDate)
+		if nextCursor(validatedParams, releases.Releases, cfg) != nil {
+			calendar.LoadMore = mapper.CreateLoadMore(validatedParams, cfg)
+		}
+		setCanonicalURL(w, cfg, &calendar, canonicalURL(cfg, validatedParams))
 		rc.BuildPage(w, calendar, "calendar")
 	})
 }
 
-func ReleaseCalendarData(cfg config.Config, api SearchAPI) http.HandlerFunc {
+// ReleaseCalendarMore renders only the entries of the calendar list that follow the cursor, for adding to the page
+// in place of loading the next page
+func ReleaseCalendarMore(cfg config.Config, rc RenderClient, api SearchAPI) http.HandlerFunc {
 	return dphandlers.ControllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, accessToken string) {
 		ctx := r.Context()
 		params := r.URL.Query()
 
 		validatedParams, err := validateParams(ctx, params, cfg)
 		if err != nil {
-			setStatusCode(r, w, err)
+			writeProblem(w, r, lang, err)
 			return
 		}
 
-		releases, err := api.GetReleases(ctx, accessToken, collectionID, lang, validatedParams.AsBackendQuery())
+		cursor, err := decodeReleaseCursor(params.Get(queryparams.Cursor), cfg.CursorSecret, validatedParams.Sort)
+		if err == nil && cursor == nil {
+			err = errors.New("cursor is required")
+		}
 		if err != nil {
-			setStatusCode(r, w, err)
+			writeProblem(w, r, lang, &validationErr{errs: []paramError{
+				newParamError(queryparams.Cursor, queryparams.ErrCodeInvalidValue, err, "ValidationCursor"),
+			}})
 			return
 		}
 
-		data, err := json.Marshal(releases)
+		releases, next, err := getReleasesAfter(ctx, validatedParams, *cursor, accessToken, collectionID, lang, cfg, api)
 		if err != nil {
 			setStatusCode(r, w, err)
 			return
 		}
 
+		// the breakdown may only count the releases from the day of the cursor, so the entries are mapped as a first
+		// page and then placed in the list by the page they stand in for
+		first := validatedParams
+		first.Page, first.Offset = 1, 0
+		calendar := mapper.CreateReleaseCalendar(rc.NewBasePageModel(), first, releases, cfg, lang, "", zebedee.EmergencyBanner{}, nil)
+		calendar.Features = featureflags.FromContext(ctx)
+		calendar.Pagination.CurrentPage = validatedParams.Page
+		calendar.TotalSearchPosition = validatedParams.Offset
+		mapper.MarkContinuedDay(calendar.Entries.Days, cursor.ReleaseDate)
+		if next != nil {
+			calendar.LoadMore = mapper.CreateLoadMore(validatedParams, cfg)
+		}
+		rc.BuildPage(w, calendar, "calendar-more")
+	})
+}
+
+// canonicalDefaults returns the values that calendar parameters take when they are not given
+func canonicalDefaults(cfg config.Config) queryparams.Defaults {
+	return queryparams.Defaults{
+		Limit:        cfg.DefaultLimit,
+		Sort:         cfg.DefaultSort,
+		ReleaseTypes: defaultReleaseTypes,
+	}
+}
+
+// canonicalURL returns the URL at which the calendar shows the search described by the parameters
+func canonicalURL(cfg config.Config, vp queryparams.ValidatedParams) string {
+	query := vp.CanonicalQuery(canonicalDefaults(cfg))
+	if len(query) == 0 {
+		return cfg.CalendarPath()
+	}
+	return cfg.CalendarPath() + "?" + query.Encode()
+}
+
+// setCanonicalURL gives the calendar page its canonical URL, which is also sent in a Link header
+func setCanonicalURL(w http.ResponseWriter, cfg config.Config, calendar *model.Calendar, canonical string) {
+	mapper.SetCanonicalURL(cfg, calendar, canonical)
+	w.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"canonical\"", canonical))
+}
+
+// getListReleases returns a page of releases for the calendar list. When the list is grouped by day and is not on the
+// first page, the release before the page is also requested so that a day continuing from the previous page can be
+// marked; its release date is returned separately.
+func getListReleases(ctx context.Context, vp queryparams.ValidatedParams, accessToken, collectionID, lang string, api SearchAPI) (search.ReleaseResponse, string, error) {
+	if !mapper.GroupsByDay(vp) || vp.Offset == 0 {
+		releases, err := getReleases(ctx, api, accessToken, collectionID, lang, vp.AsBackendQuery())
+		return releases, "", err
+	}
+
+	vp.Offset--
+	vp.Limit++
+	releases, err := getReleases(ctx, api, accessToken, collectionID, lang, vp.AsBackendQuery())
+	if err != nil || len(releases.Releases) == 0 {
+		return releases, "", err
+	}
+
+	previousReleaseDate := releases.Releases[0].Description.ReleaseDate
+	releases.Releases = releases.Releases[1:]
+	return releases, previousReleaseDate, nil
+}
+
+// releasesPage is a page of the calendar data, with the cursor that requests the page after it
+type releasesPage struct {
+	search.ReleaseResponse
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func ReleaseCalendarData(cfg config.Config, api SearchAPI) http.HandlerFunc {
+	return dphandlers.ControllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, accessToken string) {
+		ctx := r.Context()
+		params := r.URL.Query()
+
+		validatedParams, err := validateParams(ctx, params, cfg)
+		if err != nil {
+			writeProblem(w, r, lang, err)
+			return
+		}
+
+		cursor, err := decodeReleaseCursor(params.Get(queryparams.Cursor), cfg.CursorSecret, validatedParams.Sort)
+		if err != nil {
+			writeProblem(w, r, lang, &validationErr{errs: []paramError{
+				newParamError(queryparams.Cursor, queryparams.ErrCodeInvalidValue, err, "ValidationCursor"),
+			}})
+			return
+		}
+
+		var page releasesPage
+		var next *releaseCursor
+		if cursor != nil {
+			page.ReleaseResponse, next, err = getReleasesAfter(ctx, validatedParams, *cursor, accessToken, collectionID, lang, cfg, api)
+		} else {
+			page.ReleaseResponse, err = getReleases(ctx, api, accessToken, collectionID, lang, validatedParams.AsBackendQuery())
+			next = nextCursor(validatedParams, page.Releases, cfg)
+		}
+		if err != nil {
+			writeProblem(w, r, lang, err)
+			return
+		}
+		if next != nil {
+			page.NextCursor = next.encode(cfg.CursorSecret)
+		}
+
+		data, err := json.Marshal(page)
+		if err != nil {
+			writeProblem(w, r, lang, err)
+			return
+		}
+
 		w.Header().Set("content-type", "application/json")
 		if _, err = w.Write(data); err != nil {
-			setStatusCode(r, w, err)
+			writeProblem(w, r, lang, err)
 			return
 		}
 	})
 }
 
-func validateParamsAsFrontend(ctx context.Context, params url.Values, cfg config.Config) (vp queryparams.ValidatedParams, validationErrs []core.ErrorItem) {
+// validateParamsAsFrontend validates the query parameters, collecting every validation error
+func validateParamsAsFrontend(ctx context.Context, params url.Values, cfg config.Config) (vp queryparams.ValidatedParams, validationErrs []paramError) {
 	validatedParams := queryparams.ValidatedParams{}
 
 	limit, err := queryparams.GetLimit(ctx, params, cfg.DefaultLimit, cfg.DefaultMaximumLimit)
 	if err != nil {
-		validationErrs = append(validationErrs, core.ErrorItem{
-			Description: core.Localisation{
-				Text: err.Error(),
-			},
-		})
+		validationErrs = append(validationErrs, newParamError(queryparams.Limit, queryparams.ErrCodeInvalidValue, err,
+			"ValidationLimit", strconv.Itoa(cfg.DefaultMaximumLimit)))
 	}
 	validatedParams.Limit = limit
 
-	pageNumber, err := queryparams.GetPage(ctx, params, cfg.DefaultMaximumSearchResults/cfg.DefaultLimit)
+	// the last page that can be requested depends on the number of releases on each page
+	pageSize := limit
+	if pageSize <= 0 {
+		pageSize = cfg.DefaultLimit
+	}
+	maxPage := queryparams.MaximumPage(cfg.DefaultMaximumSearchResults, pageSize)
+	pageNumber, err := queryparams.GetPage(ctx, params, maxPage)
 	if err != nil {
-		validationErrs = append(validationErrs, core.ErrorItem{
-			Description: core.Localisation{
-				Text: err.Error(),
-			},
-		})
+		validationErrs = append(validationErrs, newParamError(queryparams.Page, queryparams.ErrCodeInvalidValue, err,
+			"ValidationPage", strconv.Itoa(maxPage)))
 	}
 	validatedParams.Page = pageNumber
 
 	validatedParams.Offset = queryparams.CalculateOffset(pageNumber, limit)
 
-	fromDate, vErrs := queryparams.GetStartDate(params)
-	if len(vErrs) > 0 {
-		validationErrs = append(validationErrs, vErrs...)
+	datePreset, err := queryparams.GetDatePreset(ctx, params)
+	if err != nil {
+		validationErrs = append(validationErrs, newParamError(queryparams.DateRange, queryparams.ErrCodeInvalidValue, err, "ValidationDateRangeOption"))
 	}
-	validatedParams.AfterDate = fromDate
+	validatedParams.DatePreset = datePreset
 
-	toDate, vErrs := queryparams.GetEndDate(params)
-	if len(vErrs) > 0 {
-		validationErrs = append(validationErrs, vErrs...)
-	}
-	if fromDate.String() != "" && toDate.String() != "" {
-		toDate, err = queryparams.ValidateDateRange(fromDate, toDate)
-		if err != nil {
-			validationErrs = append(validationErrs, core.ErrorItem{
-				Description: core.Localisation{
-					Text: queryparams.CapitalizeFirstLetter(err.Error()),
-				},
-				ID:  queryparams.DateToErr,
-				URL: fmt.Sprintf("#%s", queryparams.DateToErr),
-			})
-		}
+	if datePreset != queryparams.NoDatePreset {
+		validatedParams.AfterDate, validatedParams.BeforeDate = datePreset.Dates(cfg.Now())
+	} else {
+		var dateErrs []paramError
+		validatedParams.AfterDate, validatedParams.BeforeDate, dateErrs = validateDates(params)
+		validationErrs = append(validationErrs, dateErrs...)
 	}
-	validatedParams.BeforeDate = toDate
 
 	sort, err := queryparams.GetSortOrder(ctx, params, cfg.DefaultSort)
 	if err != nil {
-		validationErrs = append(validationErrs, core.ErrorItem{
-			Description: core.Localisation{
-				Text: err.Error(),
-			},
-		})
+		validationErrs = append(validationErrs, newParamError(queryparams.SortName, queryparams.ErrCodeInvalidValue, err, "ValidationSort"))
 	}
 	validatedParams.Sort = sort
 
 	keywords, err := queryparams.GetKeywords(ctx, params, "")
 	if err != nil {
-		validationErrs = append(validationErrs, core.ErrorItem{
-			Description: core.Localisation{
-				Text: err.Error(),
-			},
-		})
+		validationErrs = append(validationErrs, keywordsParamError(err))
 	}
 	validatedParams.Keywords = keywords
 
-	releaseType, err := queryparams.GetReleaseType(ctx, params, queryparams.Published)
+	releaseTypes, err := queryparams.GetReleaseTypes(ctx, params, defaultReleaseTypes)
 	if err != nil {
-		validationErrs = append(validationErrs, core.ErrorItem{
-			Description: core.Localisation{
-				Text: err.Error(),
-			},
-		})
+		validationErrs = append(validationErrs, newParamError(queryparams.Type, queryparams.ErrCodeInvalidValue, err, "ValidationReleaseType"))
+	}
+	validatedParams.ReleaseTypes = releaseTypes
+	// releases of different types are merged in sort order, which relevance does not give
+	if sort == queryparams.Relevance && len(releaseTypes) > 1 {
+		validationErrs = append(validationErrs, newParamError(queryparams.SortName, queryparams.ErrCodeInvalidValue,
+			errors.New("invalid sort parameter: relevance can only be used with a single release type"), "ValidationSortRelevance"))
 	}
-	validatedParams.ReleaseType = releaseType
-
-	validatedParams.Provisional, _ = queryparams.GetBoolean(ctx, params, queryparams.Provisional.String(), false)
-	validatedParams.Confirmed, _ = queryparams.GetBoolean(ctx, params, queryparams.Confirmed.String(), false)
-	validatedParams.Postponed, _ = queryparams.GetBoolean(ctx, params, queryparams.Postponed.String(), false)
-	validatedParams.Census, _ = queryparams.GetBoolean(ctx, params, queryparams.Census, false)
-	validatedParams.Highlight, _ = queryparams.GetBoolean(ctx, params, queryparams.Highlight, true)
 
-	return validatedParams, validationErrs
-}
+	booleans := []struct {
+		name         string
+		value        *bool
+		defaultValue bool
+	}{
+		{queryparams.Provisional.String(), &validatedParams.Provisional, false},
+		{queryparams.Confirmed.String(), &validatedParams.Confirmed, false},
+		{queryparams.Postponed.String(), &validatedParams.Postponed, false},
+		{queryparams.Census, &validatedParams.Census, false},
+		{queryparams.Highlight, &validatedParams.Highlight, true},
+	}
+	for _, b := range booleans {
+		if *b.value, err = queryparams.GetBoolean(ctx, params, b.name, b.defaultValue); err != nil {
+			validationErrs = append(validationErrs, newParamError(b.name, queryparams.ErrCodeInvalidValue, err, "ValidationBoolean", b.name))
+		}
+	}
 
-func validateParams(ctx context.Context, params url.Values, cfg config.Config) (queryparams.ValidatedParams, error) {
-	validatedParams := queryparams.ValidatedParams{}
+	groupByDay, err := queryparams.GetBoolean(ctx, params, queryparams.GroupByDay, true)
+	if err != nil {
+		validationErrs = append(validationErrs, newParamError(queryparams.GroupByDay, queryparams.ErrCodeInvalidValue, err, "ValidationBoolean", queryparams.GroupByDay))
+	}
+	validatedParams.Ungrouped = !groupByDay
 
-	limit, err := queryparams.GetLimit(ctx, params, cfg.DefaultLimit, cfg.DefaultMaximumLimit)
+	view, err := queryparams.GetView(ctx, params)
 	if err != nil {
-		return validatedParams, &clientErr{err}
+		validationErrs = append(validationErrs, newParamError(queryparams.ViewName, queryparams.ErrCodeInvalidValue, err, "ValidationView"))
 	}
-	validatedParams.Limit = limit
+	validatedParams.View = view
 
-	pageNumber, err := queryparams.GetPage(ctx, params, cfg.DefaultMaximumSearchResults/cfg.DefaultLimit)
+	viewDate, err := queryparams.GetViewDate(ctx, params)
 	if err != nil {
-		return validatedParams, &clientErr{err}
+		validationErrs = append(validationErrs, newParamError(queryparams.ViewDate, queryparams.ErrCodeInvalidValue, err, "ValidationViewDate"))
 	}
-	validatedParams.Page = pageNumber
+	validatedParams.ViewDate = viewDate
 
-	validatedParams.Offset = queryparams.CalculateOffset(pageNumber, limit)
+	return validatedParams, validationErrs
+}
 
+// validateDates validates the released after and before dates, given as year, month and day parameters
+func validateDates(params url.Values) (fromDate, toDate queryparams.Date, validationErrs []paramError) {
 	fromDate, vErrs := queryparams.GetStartDate(params)
 	if len(vErrs) > 0 {
-		for _, err := range vErrs {
-			log.Error(ctx, "invalid date", fmt.Errorf("startdate field error: %s", err.Description.Text))
-		}
-		return validatedParams, &clientErr{fmt.Errorf("invalid startDate")}
+		validationErrs = append(validationErrs, dateParamErrors(vErrs, fromDate)...)
 	}
-	validatedParams.AfterDate = fromDate
 
-	toDate, vErrs := queryparams.GetEndDate(params)
+	toDate, vErrs = queryparams.GetEndDate(params)
 	if len(vErrs) > 0 {
-		for _, err := range vErrs {
-			log.Error(ctx, "invalid date", fmt.Errorf("endDate field error: %s", err.Description.Text))
-		}
-		return validatedParams, &clientErr{fmt.Errorf("invalid endDate")}
+		validationErrs = append(validationErrs, dateParamErrors(vErrs, toDate)...)
 	}
-	validatedParams.BeforeDate = toDate
-
 	if fromDate.String() != "" && toDate.String() != "" {
-		_, err = queryparams.ValidateDateRange(fromDate, toDate)
+		var err error
+		toDate, err = queryparams.ValidateDateRange(fromDate, toDate)
 		if err != nil {
-			return validatedParams, &clientErr{err}
+			param := queryparams.YearBefore
+			if params.Get(queryparams.YearBefore) == "" {
+				param = queryparams.DateTo
+			}
+			validationErrs = append(validationErrs, paramError{
+				ParamError: queryparams.ParamError{Param: param, Code: queryparams.ErrCodeInvalidDateRange},
+				item: core.ErrorItem{
+					Description: core.Localisation{
+						Text: queryparams.CapitalizeFirstLetter(err.Error()),
+					},
+					ID:  queryparams.DateToErr,
+					URL: fmt.Sprintf("#%s", queryparams.DateToErr),
+				},
+				key: "ValidationDateOrder",
+			})
 		}
 	}
 
-	sort, err := queryparams.GetSortOrder(ctx, params, cfg.DefaultSort)
-	if err != nil {
-		return validatedParams, &clientErr{err}
-	}
-	validatedParams.Sort = sort
+	return fromDate, toDate, validationErrs
+}
 
-	keywords, err := queryparams.GetKeywords(ctx, params, "")
-	if err != nil {
-		return validatedParams, &clientErr{err}
+// keywordsParamError returns the validation error for invalid keywords, described by what is wrong with them
+func keywordsParamError(err error) paramError {
+	if errors.Is(err, queryparams.ErrKeywordsTooLong) {
+		return newParamError(queryparams.Keywords, queryparams.ErrCodeInvalidValue, err,
+			"ValidationKeywordsLength", strconv.Itoa(queryparams.MaxKeywordsLength))
 	}
-	validatedParams.Keywords = keywords
+	return newParamError(queryparams.Keywords, queryparams.ErrCodeInvalidValue, err, "ValidationKeywordsControlCharacters")
+}
 
-	releaseType, err := queryparams.GetReleaseType(ctx, params, queryparams.Published)
-	if err != nil {
-		return validatedParams, &clientErr{err}
+// validateParams validates the query parameters, returning a client error describing every parameter that failed
+func validateParams(ctx context.Context, params url.Values, cfg config.Config) (queryparams.ValidatedParams, error) {
+	validatedParams, validationErrs := validateParamsAsFrontend(ctx, params, cfg)
+	if len(validationErrs) > 0 {
+		return validatedParams, &validationErr{errs: validationErrs}
 	}
-	validatedParams.ReleaseType = releaseType
-
-	validatedParams.Provisional, _ = queryparams.GetBoolean(ctx, params, queryparams.Provisional.String(), false)
-	validatedParams.Confirmed, _ = queryparams.GetBoolean(ctx, params, queryparams.Confirmed.String(), false)
-	validatedParams.Postponed, _ = queryparams.GetBoolean(ctx, params, queryparams.Postponed.String(), false)
-	validatedParams.Census, _ = queryparams.GetBoolean(ctx, params, queryparams.Census, false)
-	validatedParams.Highlight, _ = queryparams.GetBoolean(ctx, params, queryparams.Highlight, true)
 
 	return validatedParams, nil
 }
@@ -347,20 +487,31 @@ func releaseCalendarICSEntries(w http.ResponseWriter, req *http.Request, userAcc
 	ctx := req.Context()
 	params := req.URL.Query()
 
+	// search any keywords as the Search API expects them
+	keywords, err := queryparams.GetKeywords(ctx, params, "")
+	if err != nil {
+		writeProblem(w, req, lang, &validationErr{errs: []paramError{keywordsParamError(err)}})
+		return
+	}
+	params.Del(queryparams.Keywords)
+	if keywords != "" {
+		params.Set(queryparams.Query, queryparams.ParseKeywords(keywords).BackendString())
+	}
+
 	params.Set(queryparams.Limit, strconv.Itoa(cfg.DefaultMaximumSearchResults))
 	params.Set(queryparams.SortName, queryparams.RelDateAsc.BackendString())
 	params.Set(queryparams.DateTo, time.Now().AddDate(0, 3, 0).Format(queryparams.DateFormat))
 	params.Set(queryparams.Type, queryparams.Upcoming.String())
 
-	releases, err := api.GetReleases(ctx, userAccessToken, collectionID, lang, params)
+	releases, err := getReleases(ctx, api, userAccessToken, collectionID, lang, params)
 	if err != nil {
-		setStatusCode(req, w, err)
+		writeProblem(w, req, lang, err)
 		return
 	}
 
 	fileWriter := new(bytes.Buffer)
 	if err = toICSFile(ctx, releases.Releases, fileWriter); err != nil {
-		setStatusCode(req, w, err)
+		writeProblem(w, req, lang, err)
 		return
 	}
 
@@ -368,7 +519,7 @@ func releaseCalendarICSEntries(w http.ResponseWriter, req *http.Request, userAcc
 	w.Header().Set("Character-Encoding", "UTF8")
 	w.Header().Set("Content-Disposition", "attachment; filename=releases.ics")
 	if _, err = w.Write(fileWriter.Bytes()); err != nil {
-		setStatusCode(req, w, err)
+		writeProblem(w, req, lang, err)
 		return
 	}
 }
@@ -435,7 +586,7 @@ func releaseStatus(r search.Release) string {
 func createRSSFeed(ctx context.Context, w http.ResponseWriter, r *http.Request, lang, collectionID, accessToken string, api SearchAPI, validatedParams queryparams.ValidatedParams) error {
 	var err error
 	uriPrefix := "https://www.ons.gov.uk"
-	releases, err := api.GetReleases(ctx, accessToken, collectionID, lang, validatedParams.AsBackendQuery())
+	releases, err := getReleases(ctx, api, accessToken, collectionID, lang, validatedParams.AsBackendQuery())
 	if err != nil {
 		setStatusCode(r, w, err)
 		return err
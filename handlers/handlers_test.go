@@ -6,9 +6,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -21,6 +23,7 @@ import (
 	sitesearch "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
 	"github.com/ONSdigital/dp-frontend-release-calendar/config"
 	"github.com/ONSdigital/dp-frontend-release-calendar/mocks"
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
 	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
 
 	"github.com/golang/mock/gomock"
@@ -336,6 +339,153 @@ func TestUnitHandlers(t *testing.T) {
 							So(w.Code, ShouldEqual, http.StatusOK)
 						})
 					})
+
+					Convey("When a later page of a list sorted by date is requested", func() {
+						mockZebedeeClient.EXPECT().GetHomepageContent(ctx, "", "", lang, "/")
+						mockRenderClient.EXPECT().NewBasePageModel()
+
+						expected := defaultParams()
+						expected.Set("limit", "11")
+						expected.Set("page", "2")
+						expected.Set("offset", "9")
+						previous := sitesearch.Release{URI: "/releases/previous", Description: sitesearch.ReleaseDescription{ReleaseDate: "2026-10-20T06:00:00Z"}}
+						current := sitesearch.Release{URI: "/releases/current", Description: sitesearch.ReleaseDescription{ReleaseDate: "2026-10-20T09:30:00Z"}}
+						mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, expected).Return(sitesearch.ReleaseResponse{
+							Breakdown: sitesearch.Breakdown{Total: 11},
+							Releases:  []sitesearch.Release{previous, current},
+						}, nil)
+
+						var page interface{}
+						mockRenderClient.EXPECT().BuildPage(w, gomock.Any(), "calendar").Do(func(_ io.Writer, p interface{}, _ string) {
+							page = p
+						})
+
+						req := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:27700%s?page=2", endpoint), http.NoBody)
+
+						Convey("Then the release before the page is requested and used to mark the first day as continued", func() {
+							router.ServeHTTP(w, req)
+
+							So(w.Code, ShouldEqual, http.StatusOK)
+							calendar, ok := page.(model.Calendar)
+							So(ok, ShouldBeTrue)
+							So(calendar.Entries.Items, ShouldHaveLength, 1)
+							So(calendar.Entries.Items[0].URI, ShouldEqual, current.URI)
+							So(calendar.Entries.Days, ShouldHaveLength, 1)
+							So(calendar.Entries.Days[0].Continued, ShouldBeTrue)
+						})
+					})
+
+					Convey("When a date preset is requested", func() {
+						cfg := *mockConfig
+						cfg.Clock = func() time.Time { return time.Date(2026, 10, 18, 23, 30, 0, 0, time.UTC) }
+						router := mux.NewRouter()
+						router.HandleFunc(endpoint, ReleaseCalendar(cfg, mockRenderClient, mockSearchClient, mockZebedeeClient))
+
+						mockRenderClient.EXPECT().NewBasePageModel()
+						mockZebedeeClient.EXPECT().GetHomepageContent(ctx, "", "", lang, "/")
+
+						var query url.Values
+						mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, gomock.Any()).DoAndReturn(
+							func(_ context.Context, _, _, _ string, q url.Values) (sitesearch.ReleaseResponse, error) {
+								query = q
+								return r, nil
+							})
+
+						var page interface{}
+						mockRenderClient.EXPECT().BuildPage(w, gomock.Any(), "calendar").Do(func(_ io.Writer, p interface{}, _ string) {
+							page = p
+						})
+
+						req := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:27700%s?date-range=this-week", endpoint), http.NoBody)
+
+						Convey("Then it is resolved in Europe/London time, and kept in links", func() {
+							router.ServeHTTP(w, req)
+
+							So(w.Code, ShouldEqual, http.StatusOK)
+							So(query.Get(queryparams.DateFrom), ShouldEqual, "2026-10-19")
+							So(query.Get(queryparams.DateTo), ShouldEqual, "2026-10-25")
+
+							calendar, ok := page.(model.Calendar)
+							So(ok, ShouldBeTrue)
+							So(calendar.RSSLink, ShouldContainSubstring, "date-range=this-week")
+							So(calendar.RSSLink, ShouldNotContainSubstring, "after-year")
+							So(calendar.FuncIsDatePresetChecked(), ShouldBeTrue)
+						})
+					})
+
+					Convey("When a month view is requested", func() {
+						mockRenderClient.EXPECT().NewBasePageModel()
+						mockRenderClient.EXPECT().BuildPage(w, gomock.Any(), "calendar")
+						mockZebedeeClient.EXPECT().GetHomepageContent(ctx, "", "", lang, "/")
+
+						var query url.Values
+						mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, gomock.Any()).DoAndReturn(
+							func(_ context.Context, _, _, _ string, q url.Values) (sitesearch.ReleaseResponse, error) {
+								query = q
+								return r, nil
+							})
+
+						req := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:27700%s?after-day=10&after-month=2&after-year=2026&date=2026-02-14&view=month", endpoint), http.NoBody)
+
+						Convey("Then the releases in the month are requested, narrowed by the date filters", func() {
+							router.ServeHTTP(w, req)
+
+							So(w.Code, ShouldEqual, http.StatusOK)
+							So(query.Get(queryparams.DateFrom), ShouldEqual, "2026-02-10")
+							So(query.Get(queryparams.DateTo), ShouldEqual, "2026-02-28")
+							So(query.Get(queryparams.Limit), ShouldEqual, strconv.Itoa(mockConfig.DefaultMaximumLimit))
+						})
+					})
+
+					Convey("When the parameters are not in their canonical form", func() {
+						mockZebedeeClient.EXPECT().GetHomepageContent(ctx, "", "", lang, "/")
+
+						req := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:27700%s?page=1&limit=%d&keywords=++gdp++wales&date-range=this-week&after-year=2020&utm_source=email", endpoint, mockConfig.DefaultLimit), http.NoBody)
+
+						Convey("Then it redirects permanently to the canonical URL", func() {
+							router.ServeHTTP(w, req)
+
+							So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+							So(w.Header().Get("Location"), ShouldEqual, endpoint+"?date-range=this-week&keywords=gdp+wales")
+						})
+					})
+
+					Convey("When the parameters are canonical but in a different order", func() {
+						mockZebedeeClient.EXPECT().GetHomepageContent(ctx, "", "", lang, "/")
+
+						req := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:27700%s?release-type=type-upcoming&page=2", endpoint), http.NoBody)
+
+						Convey("Then it redirects permanently to the parameters in their canonical order", func() {
+							router.ServeHTTP(w, req)
+
+							So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+							So(w.Header().Get("Location"), ShouldEqual, endpoint+"?page=2&release-type=type-upcoming")
+						})
+					})
+
+					Convey("When the parameters are canonical", func() {
+						mockZebedeeClient.EXPECT().GetHomepageContent(ctx, "", "", lang, "/")
+						mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, gomock.Any()).Return(r, nil)
+						mockRenderClient.EXPECT().NewBasePageModel()
+
+						var page interface{}
+						mockRenderClient.EXPECT().BuildPage(w, gomock.Any(), "calendar").Do(func(_ io.Writer, p interface{}, _ string) {
+							page = p
+						})
+
+						req := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:27700%s?page=2&release-type=type-upcoming", endpoint), http.NoBody)
+
+						Convey("Then the page is shown with its canonical URL", func() {
+							router.ServeHTTP(w, req)
+
+							canonical := endpoint + "?page=2&release-type=type-upcoming"
+							So(w.Code, ShouldEqual, http.StatusOK)
+							So(w.Header().Get("Link"), ShouldEqual, "<"+canonical+">; rel=\"canonical\"")
+							calendar, ok := page.(model.Calendar)
+							So(ok, ShouldBeTrue)
+							So(calendar.URI, ShouldEqual, canonical)
+						})
+					})
 				})
 			})
 
@@ -513,6 +663,21 @@ func TestUnitHandlers(t *testing.T) {
 
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
 			})
+
+			Convey("it passes on the keywords and census filter of the ICS link", func() {
+				expected := defaultICSParams()
+				expected.Set(queryparams.Query, "gdp")
+				expected.Set(queryparams.Census, "true")
+				mockSearchClient.EXPECT().GetReleases(ctx, accessToken, collectionID, lang, expected).Return(sitesearch.ReleaseResponse{}, nil)
+				req := httptest.NewRequest("GET", fmt.Sprintf("http://localhost:27700%s?census=true&keywords=gdp", endpoint), http.NoBody)
+				if err := setRequestHeaders(req); err != nil {
+					t.Fatalf("unable to set request headers, error: %v", err)
+				}
+
+				router.ServeHTTP(w, req)
+
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
 		})
 	})
 }
@@ -550,6 +715,43 @@ func defaultICSParams() url.Values {
 	return values
 }
 
+func TestValidatePageForLimit(t *testing.T) {
+	cfg, _ := config.Get()
+	testcases := []struct {
+		query     string
+		validPage bool
+		errs      int
+	}{
+		{query: "page=100", validPage: true},
+		{query: "page=101", validPage: false, errs: 1},
+		{query: "limit=25&page=40", validPage: true},
+		{query: "limit=25&page=41", validPage: false, errs: 1},
+		{query: "limit=30&page=34", validPage: true},
+		{query: "limit=30&page=35", validPage: false, errs: 1},
+		{query: "limit=100&page=10", validPage: true},
+		{query: "limit=100&page=11", validPage: false, errs: 1},
+		// an invalid limit is reported alone, with the page checked against the default limit
+		{query: "limit=101&page=100", validPage: true, errs: 1},
+	}
+
+	for _, tc := range testcases {
+		Convey("Given the query "+tc.query, t, func() {
+			params, err := url.ParseQuery(tc.query)
+			So(err, ShouldBeNil)
+
+			Convey("Then the page is validated against the pages of the requested size", func() {
+				_, validationErrs := validateParamsAsFrontend(context.Background(), params, *cfg)
+				So(validationErrs, ShouldHaveLength, tc.errs)
+				pageErr := false
+				for _, e := range validationErrs {
+					pageErr = pageErr || e.Param == queryparams.Page
+				}
+				So(pageErr, ShouldEqual, !tc.validPage)
+			})
+		})
+	}
+}
+
 func TestICalDate(t *testing.T) {
 	ds := []struct{ date, expected string }{
 		{date: "1st Jan 2020", expected: ""},
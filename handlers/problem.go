@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ONSdigital/dis-design-system-go/v2/helper"
+	core "github.com/ONSdigital/dis-design-system-go/v2/model"
+	"github.com/ONSdigital/dp-frontend-release-calendar/api"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const problemContentType = "application/problem+json"
+
+// paramError is a validation error for a query parameter, as shown on the calendar page. An error with a locale key
+// is described by it in a problem details response, in the language of the request.
+type paramError struct {
+	queryparams.ParamError
+	item core.ErrorItem
+	key  string
+	args []string
+}
+
+func newParamError(param, code string, err error, key string, args ...string) paramError {
+	return paramError{
+		ParamError: queryparams.ParamError{Param: param, Code: code},
+		item:       core.ErrorItem{Description: core.Localisation{Text: err.Error()}},
+		key:        key,
+		args:       args,
+	}
+}
+
+// description returns the description of the error in lang
+func (pe paramError) description(lang string) string {
+	if pe.key == "" {
+		return pe.item.Description.FuncLocalise(lang)
+	}
+	return helper.Localise(pe.key, lang, 1, pe.args...)
+}
+
+// dateParamErrors pairs the validation errors for a date with the parameters responsible for them
+func dateParamErrors(items []core.ErrorItem, date queryparams.Date) []paramError {
+	params := date.ParamErrors()
+	errs := make([]paramError, 0, len(items))
+	for i := range items {
+		pe := paramError{item: items[i]}
+		if i < len(params) {
+			pe.ParamError = params[i]
+		}
+		errs = append(errs, pe)
+	}
+	return errs
+}
+
+// errorItems returns the error items of validation errors, for display on a page
+func errorItems(errs []paramError) []core.ErrorItem {
+	if len(errs) == 0 {
+		return nil
+	}
+	items := make([]core.ErrorItem, 0, len(errs))
+	for i := range errs {
+		items = append(items, errs[i].item)
+	}
+	return items
+}
+
+// validationErr is a client error holding every query parameter that failed validation
+type validationErr struct {
+	errs []paramError
+}
+
+func (e *validationErr) Error() string {
+	descriptions := make([]string, 0, len(e.errs))
+	for i := range e.errs {
+		descriptions = append(descriptions, e.errs[i].Param+": "+e.errs[i].item.Description.FuncLocalise("en"))
+	}
+	return "invalid query parameters: " + strings.Join(descriptions, "; ")
+}
+
+func (e *validationErr) Code() int {
+	return http.StatusBadRequest
+}
+
+// writeProblem writes the status code for an error and an RFC 9457 problem details body describing it. Only the
+// details of errors caused by invalid client input are returned, so as not to leak details of upstream services.
+func writeProblem(w http.ResponseWriter, r *http.Request, lang string, err error) {
+	status := http.StatusInternalServerError
+	problem := api.Problem{Type: "about:blank"}
+
+	var cliErr ClientError
+	if errors.As(err, &cliErr) {
+		status = cliErr.Code()
+		log.Info(r.Context(), "setting client error response status", log.Data{"status": status})
+	} else {
+		log.Error(r.Context(), "setting internal error response status", err)
+	}
+
+	var vErr *validationErr
+	var cErr *clientErr
+	switch {
+	case errors.As(err, &vErr):
+		problem.Detail = helper.Localise("ValidationInvalidParameters", lang, len(vErr.errs))
+		for i := range vErr.errs {
+			problem.Errors = append(problem.Errors, api.ProblemError{
+				Parameter: vErr.errs[i].Param,
+				Code:      vErr.errs[i].Code,
+				Detail:    vErr.errs[i].description(lang),
+			})
+		}
+	case errors.As(err, &cErr):
+		problem.Detail = cErr.Error()
+	}
+	problem.Status = status
+	problem.Title = http.StatusText(status)
+
+	data, err := json.Marshal(problem)
+	if err != nil {
+		log.Error(r.Context(), "failed to marshal problem details", err)
+		w.WriteHeader(status)
+		return
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(status)
+	if _, err = w.Write(data); err != nil {
+		log.Error(r.Context(), "failed to write problem details", err)
+	}
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-design-system-go/v2/helper"
+	"github.com/ONSdigital/dp-frontend-release-calendar/api"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWriteProblem(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+	Convey("Given a request with several invalid query parameters", t, func() {
+		cfg, _ := config.Get()
+		req := httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/data?limit=bad&sort=sideways&after-day=30&after-month=2&after-year=2021&census=maybe", http.NoBody)
+
+		_, err := validateParams(req.Context(), req.URL.Query(), *cfg)
+		So(err, ShouldNotBeNil)
+
+		Convey("When the problem is written", func() {
+			w := httptest.NewRecorder()
+			writeProblem(w, req, "en", err)
+
+			Convey("Then every failing parameter is described", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/problem+json")
+
+				var problem api.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+				So(problem.Type, ShouldEqual, "about:blank")
+				So(problem.Title, ShouldEqual, "Bad Request")
+				So(problem.Status, ShouldEqual, http.StatusBadRequest)
+				So(problem.Detail, ShouldEqual, "Some query parameters are invalid")
+				So(problem.Errors, ShouldResemble, []api.ProblemError{
+					{Parameter: "limit", Code: "invalid_value", Detail: "Enter a number of results from 0 to 100"},
+					{Parameter: "after-day", Code: "invalid_date", Detail: "Enter a real date"},
+					{Parameter: "sort", Code: "invalid_value", Detail: "Choose a sort order from the list"},
+					{Parameter: "census", Code: "invalid_value", Detail: "Enter true or false for census"},
+				})
+			})
+		})
+
+		Convey("When the problem is written in Welsh", func() {
+			w := httptest.NewRecorder()
+			writeProblem(w, req, "cy", err)
+
+			Convey("Then the details are in Welsh", func() {
+				var problem api.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+				So(problem.Detail, ShouldEqual, "Mae rhai paramedrau ymholiad yn annilys")
+				So(problem.Errors[0].Detail, ShouldEqual, "Rhowch nifer o ganlyniadau rhwng 0 a 100")
+			})
+		})
+
+		Convey("When the errors are shown on the calendar page", func() {
+			items := errorItems(err.(*validationErr).errs)
+
+			Convey("Then each keeps the message of its cause", func() {
+				So(items, ShouldHaveLength, 4)
+				So(items[0].Description.Text, ShouldEqual, "invalid limit parameter: enter a number")
+				So(items[2].Description.Text, ShouldEqual, "invalid sort parameter: invalid sort option string")
+				So(items[3].Description.Text, ShouldEqual, `invalid boolean value for parameter "census"`)
+			})
+		})
+	})
+
+	Convey("Given a before date earlier than the after date", t, func() {
+		cfg, _ := config.Get()
+		req := httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/data?after-year=2022&before-year=2021", http.NoBody)
+
+		_, err := validateParams(req.Context(), req.URL.Query(), *cfg)
+
+		Convey("When the problem is written", func() {
+			w := httptest.NewRecorder()
+			writeProblem(w, req, "en", err)
+
+			Convey("Then the before year is reported as an invalid range", func() {
+				var problem api.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+				So(problem.Detail, ShouldEqual, "A query parameter is invalid")
+				So(problem.Errors, ShouldHaveLength, 1)
+				So(problem.Errors[0].Parameter, ShouldEqual, "before-year")
+				So(problem.Errors[0].Code, ShouldEqual, "invalid_date_range")
+				So(problem.Errors[0].Detail, ShouldEqual, "Enter a released before date that is later than the released after date")
+			})
+		})
+	})
+
+	Convey("Given an ISO before date earlier than the ISO after date", t, func() {
+		cfg, _ := config.Get()
+		req := httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/data?fromDate=2022-03&toDate=2021-12-31", http.NoBody)
+
+		_, err := validateParams(req.Context(), req.URL.Query(), *cfg)
+
+		Convey("When the problem is written", func() {
+			w := httptest.NewRecorder()
+			writeProblem(w, req, "en", err)
+
+			Convey("Then the ISO before date is reported as an invalid range", func() {
+				var problem api.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+				So(problem.Errors, ShouldHaveLength, 1)
+				So(problem.Errors[0].Parameter, ShouldEqual, "toDate")
+				So(problem.Errors[0].Code, ShouldEqual, "invalid_date_range")
+			})
+		})
+	})
+
+	Convey("Given keywords that are too long", t, func() {
+		cfg, _ := config.Get()
+		req := httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/data?keywords="+strings.Repeat("a", 201), http.NoBody)
+
+		_, err := validateParams(req.Context(), req.URL.Query(), *cfg)
+
+		Convey("When the problem is written in Welsh", func() {
+			w := httptest.NewRecorder()
+			writeProblem(w, req, "cy", err)
+
+			Convey("Then the detail gives the greatest length in Welsh", func() {
+				var problem api.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+				So(problem.Errors, ShouldHaveLength, 1)
+				So(problem.Errors[0].Parameter, ShouldEqual, "keywords")
+				So(problem.Errors[0].Detail, ShouldEqual, "Rhowch eiriau allweddol sydd heb fod yn hwy na 200 nod")
+			})
+		})
+	})
+
+	Convey("Given an error from an upstream service", t, func() {
+		req := httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/data", http.NoBody)
+		w := httptest.NewRecorder()
+
+		writeProblem(w, req, "en", errors.New("connection refused"))
+
+		Convey("Then a 500 is written without the details of the error", func() {
+			So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			So(w.Body.String(), ShouldEqual, `{"type":"about:blank","title":"Internal Server Error","status":500}`)
+		})
+	})
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"maps"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	search "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+)
+
+// maxSearchLimit is the greatest number of releases the Search API returns for a single request
+const maxSearchLimit = 1000
+
+// getReleases requests the releases matching query from the Search API. The Search API only filters by the first
+// release type it is given, so when several are selected each is requested separately and the releases merged in the
+// sort order of the query.
+func getReleases(ctx context.Context, api SearchAPI, accessToken, collectionID, lang string, query url.Values) (search.ReleaseResponse, error) {
+	if len(query[queryparams.Type]) < 2 {
+		return api.GetReleases(ctx, accessToken, collectionID, lang, query)
+	}
+
+	offset, _ := strconv.Atoi(query.Get(queryparams.Offset))
+	limit, _ := strconv.Atoi(query.Get(queryparams.Limit))
+	limit = max(limit, 1)
+
+	// the releases of each type up to the end of the page are requested at once, so that the page is merged from as
+	// few requests as possible
+	m := newReleaseMerger(query, min(offset+limit, maxSearchLimit))
+	if _, err := m.next(ctx, api, accessToken, collectionID, lang, offset); err != nil {
+		return search.ReleaseResponse{}, err
+	}
+	releases, err := m.next(ctx, api, accessToken, collectionID, lang, limit)
+	if err != nil {
+		return search.ReleaseResponse{}, err
+	}
+
+	return search.ReleaseResponse{Took: m.took, Breakdown: m.breakdown(), Releases: releases}, nil
+}
+
+// releaseMerger reads through the releases of each release type of a query in its sort order. The releases of each
+// type are requested a page at a time as they are needed, so each page is only requested once.
+type releaseMerger struct {
+	lists []*releaseList
+	less  func(a, b search.Release) bool
+	took  int
+}
+
+// newReleaseMerger returns a releaseMerger for query, from its first release, that requests pageSize releases of a
+// type at a time. The
+// subtypes of upcoming releases are only sent with the query for upcoming releases, as the Search API applies them to
+// no other type.
+func newReleaseMerger(query url.Values, pageSize int) *releaseMerger {
+	releaseTypes := query[queryparams.Type]
+	m := &releaseMerger{
+		lists: make([]*releaseList, 0, len(releaseTypes)),
+		less:  releaseOrder(query.Get(queryparams.SortName)),
+	}
+	for _, rt := range releaseTypes {
+		q := maps.Clone(query)
+		q.Set(queryparams.Type, rt)
+		if len(releaseTypes) > 1 && rt != queryparams.Upcoming.Name() {
+			for _, subtype := range []queryparams.ReleaseType{queryparams.Provisional, queryparams.Confirmed, queryparams.Postponed} {
+				q.Del(subtype.String())
+			}
+		}
+		m.lists = append(m.lists, &releaseList{releaseType: rt, query: q, limit: max(pageSize, 1)})
+	}
+	return m
+}
+
+// next returns up to n releases following those already read
+func (m *releaseMerger) next(ctx context.Context, api SearchAPI, accessToken, collectionID, lang string, n int) ([]search.Release, error) {
+	releases := make([]search.Release, 0, n)
+	for len(releases) < n {
+		next := -1
+		for i, l := range m.lists {
+			if err := m.fill(ctx, l, api, accessToken, collectionID, lang); err != nil {
+				return nil, err
+			}
+			if len(l.releases) > 0 && (next < 0 || m.less(l.releases[0], m.lists[next].releases[0])) {
+				next = i
+			}
+		}
+		if next < 0 {
+			break
+		}
+		releases = append(releases, m.lists[next].releases[0])
+		m.lists[next].releases = m.lists[next].releases[1:]
+	}
+	return releases, nil
+}
+
+// done returns whether every release has been read
+func (m *releaseMerger) done() bool {
+	for _, l := range m.lists {
+		if len(l.releases) > 0 || !l.done {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *releaseMerger) fill(ctx context.Context, l *releaseList, api SearchAPI, accessToken, collectionID, lang string) error {
+	took, err := l.fill(ctx, api, accessToken, collectionID, lang)
+	m.took += took
+	return err
+}
+
+// breakdown returns the breakdown of the releases of every type. Each type requested counts towards the total, and
+// takes its counts from the response for it; the types that were not requested are counted by the Search API in the
+// response for every type.
+func (m *releaseMerger) breakdown() search.Breakdown {
+	if len(m.lists) == 0 {
+		return search.Breakdown{}
+	}
+	b := m.lists[0].breakdown
+	b.Total, b.Census = 0, 0
+	for _, l := range m.lists {
+		b.Total += l.breakdown.Total
+		b.Census += l.breakdown.Census
+		switch l.releaseType {
+		case queryparams.Upcoming.Name():
+			b.Provisional, b.Confirmed, b.Postponed = l.breakdown.Provisional, l.breakdown.Confirmed, l.breakdown.Postponed
+		case queryparams.Published.Name():
+			b.Published = l.breakdown.Published
+		case queryparams.Cancelled.Name():
+			b.Cancelled = l.breakdown.Cancelled
+		}
+	}
+	return b
+}
+
+// releaseList is the releases of a single release type that have been requested but not yet merged
+type releaseList struct {
+	releaseType string
+	query       url.Values
+	limit       int
+	offset      int
+	releases    []search.Release
+	breakdown   search.Breakdown
+	requested   bool
+	done        bool
+}
+
+// fill requests the next page of releases for the list once the previous page has been merged, returning the time
+// the Search API took
+func (l *releaseList) fill(ctx context.Context, api SearchAPI, accessToken, collectionID, lang string) (int, error) {
+	if len(l.releases) > 0 || l.done {
+		return 0, nil
+	}
+
+	l.query.Set(queryparams.Offset, strconv.Itoa(l.offset))
+	l.query.Set(queryparams.Limit, strconv.Itoa(l.limit))
+	l.query.Set(queryparams.Page, strconv.Itoa(queryparams.CalculatePageNumber(l.offset, l.limit)))
+	response, err := api.GetReleases(ctx, accessToken, collectionID, lang, maps.Clone(l.query))
+	if err != nil {
+		return 0, err
+	}
+
+	if !l.requested {
+		l.breakdown = response.Breakdown
+		l.requested = true
+	}
+	l.releases = response.Releases
+	l.offset += len(response.Releases)
+	l.done = len(response.Releases) < l.limit || l.offset >= response.Breakdown.Total
+	return response.Took, nil
+}
+
+// releaseOrder returns whether a release comes before another in the backend sort order. Releases sorted by
+// relevance cannot be compared, so are left in the order of their release types.
+func releaseOrder(sort string) func(a, b search.Release) bool {
+	switch sort {
+	case queryparams.RelDateAsc.BackendString():
+		return func(a, b search.Release) bool { return releaseTime(a).Before(releaseTime(b)) }
+	case queryparams.RelDateDesc.BackendString():
+		return func(a, b search.Release) bool { return releaseTime(a).After(releaseTime(b)) }
+	case queryparams.TitleAZ.BackendString():
+		return func(a, b search.Release) bool {
+			return strings.ToLower(a.Description.Title) < strings.ToLower(b.Description.Title)
+		}
+	case queryparams.TitleZA.BackendString():
+		return func(a, b search.Release) bool {
+			return strings.ToLower(a.Description.Title) > strings.ToLower(b.Description.Title)
+		}
+	default:
+		return func(_, _ search.Release) bool { return false }
+	}
+}
+
+func releaseTime(r search.Release) time.Time {
+	t, _ := time.Parse(time.RFC3339, r.Description.ReleaseDate)
+	return t
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	sitesearch "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetReleases(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := gomock.Any()
+
+	Convey("Given the Search API", t, func() {
+		mockSearchClient := NewMockSearchAPI(mockCtrl)
+
+		Convey("When a single release type is requested", func() {
+			query := exportParams(0, 2)
+			mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, query).
+				Return(sitesearch.ReleaseResponse{Breakdown: sitesearch.Breakdown{Total: 1}, Releases: generateReleases(0, 1)}, nil)
+
+			releases, err := getReleases(context.Background(), mockSearchClient, "", "", lang, query)
+
+			Convey("Then the query is passed on unchanged", func() {
+				So(err, ShouldBeNil)
+				So(releases.Releases, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When several release types are requested", func() {
+			byType := map[string][]sitesearch.Release{
+				queryparams.Published.Name(): {
+					datedRelease("/releases/p1", "2026-10-25T09:30:00Z"),
+					datedRelease("/releases/p2", "2026-10-23T09:30:00Z"),
+					datedRelease("/releases/p3", "2026-10-21T09:30:00Z"),
+				},
+				queryparams.Cancelled.Name(): {
+					datedRelease("/releases/c1", "2026-10-24T09:30:00Z"),
+					datedRelease("/releases/c2", "2026-10-22T09:30:00Z"),
+				},
+			}
+			// the Search API counts every type in its breakdown, but only the releases of the requested type in the total
+			// and the census count
+			breakdowns := map[string]sitesearch.Breakdown{
+				queryparams.Published.Name(): {Published: 3, Cancelled: 2, Provisional: 4, Census: 1},
+				queryparams.Cancelled.Name(): {Published: 3, Cancelled: 2, Provisional: 4, Census: 2},
+			}
+			var queries []url.Values
+			mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, gomock.Any()).DoAndReturn(
+				func(c context.Context, accessToken, collectionID, lang string, q url.Values) (sitesearch.ReleaseResponse, error) {
+					response, err := searchReleases(byType[q.Get(queryparams.Type)], &queries)(c, accessToken, collectionID, lang, q)
+					b := breakdowns[q.Get(queryparams.Type)]
+					b.Total = response.Breakdown.Total
+					response.Breakdown = b
+					return response, err
+				}).AnyTimes()
+
+			vp := queryparams.ValidatedParams{
+				Limit:        2,
+				Page:         2,
+				Offset:       2,
+				Sort:         queryparams.RelDateDesc,
+				ReleaseTypes: queryparams.NewReleaseTypes(queryparams.Published, queryparams.Cancelled),
+				Postponed:    true,
+			}
+			releases, err := getReleases(context.Background(), mockSearchClient, "", "", lang, vp.AsBackendQuery())
+
+			Convey("Then the Search API is queried once for each release type, up to the end of the page", func() {
+				So(err, ShouldBeNil)
+				So(queries, ShouldHaveLength, 2)
+				requested := map[string]bool{}
+				for _, q := range queries {
+					So(q[queryparams.Type], ShouldHaveLength, 1)
+					So(q.Get(queryparams.Offset), ShouldEqual, "0")
+					So(q.Get(queryparams.Limit), ShouldEqual, "4")
+					requested[q.Get(queryparams.Type)] = true
+				}
+				So(requested, ShouldResemble, map[string]bool{queryparams.Published.Name(): true, queryparams.Cancelled.Name(): true})
+			})
+
+			Convey("Then the subtypes of upcoming releases are not sent for other types", func() {
+				for _, q := range queries {
+					So(q.Has(queryparams.Postponed.String()), ShouldBeFalse)
+				}
+			})
+
+			Convey("Then the breakdown counts the releases of both types", func() {
+				So(releases.Breakdown, ShouldResemble, sitesearch.Breakdown{Total: 5, Published: 3, Cancelled: 2, Provisional: 4, Census: 3})
+			})
+
+			Convey("Then the page is taken from the releases of both types in date order", func() {
+				So(releases.Releases, ShouldHaveLength, 2)
+				So(releases.Releases[0].URI, ShouldEqual, "/releases/p2")
+				So(releases.Releases[1].URI, ShouldEqual, "/releases/c2")
+			})
+		})
+	})
+}
+
+func TestValidateParamsRelevanceReleaseTypes(t *testing.T) {
+	Convey("Given a search sorted by relevance", t, func() {
+		cfg, _ := config.Get()
+		target := "http://localhost:27700/releasecalendar?keywords=gdp&sort=relevance&release-type=type-published"
+
+		Convey("When several release types are selected", func() {
+			req := httptest.NewRequest("GET", target+"&release-type=type-cancelled", http.NoBody)
+			_, err := validateParams(req.Context(), req.URL.Query(), *cfg)
+
+			Convey("Then the sort is invalid", func() {
+				vErr, ok := err.(*validationErr)
+				So(ok, ShouldBeTrue)
+				So(vErr.errs, ShouldHaveLength, 1)
+				So(vErr.errs[0].Param, ShouldEqual, queryparams.SortName)
+			})
+		})
+
+		Convey("When a single release type is selected", func() {
+			req := httptest.NewRequest("GET", target, http.NoBody)
+			_, err := validateParams(req.Context(), req.URL.Query(), *cfg)
+
+			Convey("Then it is valid", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
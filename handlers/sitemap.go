@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	search "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/mapper"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	"github.com/ONSdigital/dp-frontend-release-calendar/sitemap"
+	dphandlers "github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+	"golang.org/x/sync/singleflight"
+)
+
+// sitemapReleaseTypes are the release types listed in the sitemaps, as every release has a page
+var sitemapReleaseTypes = queryparams.NewReleaseTypes(queryparams.Upcoming, queryparams.Published, queryparams.Cancelled)
+
+// Sitemaps caches the sitemaps of release pages in each language. They list up to SITEMAP_MAX_RELEASES releases in the
+// search API, so are built at most once every SITEMAP_CACHE_TTL rather than on each request. They only ever list
+// published content, so requests in a collection are served the same sitemaps as any other.
+type Sitemaps struct {
+	builds singleflight.Group // shares a build in progress, so that requests wait for it rather than starting another
+	mu     sync.Mutex         // guards sets, and is not held while building
+	sets   map[string]*sitemapSet
+}
+
+// sitemapSet is a sitemap index and the sitemaps that it lists
+type sitemapSet struct {
+	built    time.Time
+	index    []byte
+	sitemaps []sitemap.Sitemap
+}
+
+// NewSitemaps returns an empty sitemap cache
+func NewSitemaps() *Sitemaps {
+	return &Sitemaps{sets: map[string]*sitemapSet{}}
+}
+
+// SitemapIndex returns the sitemap index of the release pages, which lists the sitemaps that each list a part of them
+func SitemapIndex(cfg config.Config, searchAPI SearchAPI, sitemaps *Sitemaps) http.HandlerFunc {
+	return dphandlers.ControllerHandler(func(w http.ResponseWriter, r *http.Request, lang, _, _ string) {
+		set, err := sitemaps.get(r.Context(), cfg, searchAPI, lang)
+		if err != nil {
+			setStatusCode(r, w, err)
+			return
+		}
+		writeSitemap(w, r, set.index)
+	})
+}
+
+// Sitemap returns a sitemap listed in the sitemap index, given by its number from 1
+func Sitemap(cfg config.Config, searchAPI SearchAPI, sitemaps *Sitemaps) http.HandlerFunc {
+	return dphandlers.ControllerHandler(func(w http.ResponseWriter, r *http.Request, lang, _, _ string) {
+		page, err := strconv.Atoi(mux.Vars(r)["page"])
+		if err != nil || page < 1 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		set, err := sitemaps.get(r.Context(), cfg, searchAPI, lang)
+		if err != nil {
+			setStatusCode(r, w, err)
+			return
+		}
+		if page > len(set.sitemaps) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeSitemap(w, r, set.sitemaps[page-1].Data)
+	})
+}
+
+func writeSitemap(w http.ResponseWriter, r *http.Request, data []byte) {
+	w.Header().Set("Content-Type", sitemap.ContentType)
+	if _, err := w.Write(data); err != nil {
+		log.Warn(r.Context(), "failed to write sitemap", log.FormatErrors([]error{err}))
+	}
+}
+
+// get returns the sitemaps of lang, building them if they are not cached or were built longer than SITEMAP_CACHE_TTL
+// ago. If they cannot be built, those built before are returned until they can be.
+func (s *Sitemaps) get(ctx context.Context, cfg config.Config, searchAPI SearchAPI, lang string) (*sitemapSet, error) {
+	now := cfg.Now()
+	cached := s.cached(lang)
+	if cached != nil && now.Sub(cached.built) < cfg.SitemapCacheTTL {
+		return cached, nil
+	}
+
+	// the build is shared by every request waiting for it, so is not cancelled when the request that started it is
+	built, err, _ := s.builds.Do(lang, func() (interface{}, error) {
+		set, err := buildSitemaps(context.WithoutCancel(ctx), cfg, searchAPI, lang, now)
+		if err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		s.sets[lang] = set
+		s.mu.Unlock()
+		return set, nil
+	})
+	if err != nil {
+		if cached != nil {
+			log.Error(ctx, "failed to rebuild sitemaps, serving those built before", err, log.Data{"language": lang, "built": cached.built})
+			return cached, nil
+		}
+		return nil, err
+	}
+	return built.(*sitemapSet), nil
+}
+
+func (s *Sitemaps) cached(lang string) *sitemapSet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sets[lang]
+}
+
+// buildSitemaps requests every release from the search API and builds the sitemaps that list their pages, oldest
+// first so that the sitemaps of older releases change least. The search API only filters by a single release type,
+// so the releases of each type are requested in turn and merged, until SITEMAP_MAX_RELEASES have been.
+func buildSitemaps(ctx context.Context, cfg config.Config, searchAPI SearchAPI, lang string, now time.Time) (*sitemapSet, error) {
+	var releases []search.Release
+	for _, rt := range sitemapReleaseTypes {
+		typeReleases, err := getAllReleases(ctx, cfg, searchAPI, lang, rt, cfg.SitemapMaxReleases-len(releases))
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, typeReleases...)
+	}
+	slices.SortStableFunc(releases, func(a, b search.Release) int { return releaseTime(a).Compare(releaseTime(b)) })
+
+	urls := make([]sitemap.URL, 0, len(releases))
+	for i := range releases {
+		urls = append(urls, mapper.CreateSitemapURL(releases[i], lang, cfg, now))
+	}
+
+	sitemaps, err := sitemap.Build(urls, cfg.SitemapPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]sitemap.Location, 0, len(sitemaps))
+	for i := range sitemaps {
+		locations = append(locations, sitemap.Location{
+			Loc:     fmt.Sprintf("%s%s/sitemap-%d.xml", cfg.SiteURL(lang), cfg.CalendarPath(), i+1),
+			LastMod: sitemaps[i].LastMod,
+		})
+	}
+	index, err := sitemap.Index(locations)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx, "sitemaps built", log.Data{"language": lang, "urls": len(urls), "sitemaps": len(sitemaps)})
+	return &sitemapSet{built: now, index: index, sitemaps: sitemaps}, nil
+}
+
+// getAllReleases requests the releases of a release type from the search API a page at a time, up to maxReleases
+func getAllReleases(ctx context.Context, cfg config.Config, searchAPI SearchAPI, lang string, rt queryparams.ReleaseType,
+	maxReleases int) ([]search.Release, error) {
+	vp := queryparams.ValidatedParams{
+		Limit:        cfg.DefaultMaximumLimit,
+		Sort:         queryparams.RelDateAsc,
+		ReleaseTypes: queryparams.NewReleaseTypes(rt),
+	}
+
+	var all []search.Release
+	for offset := 0; ; {
+		if offset >= maxReleases {
+			log.Warn(ctx, "sitemaps are missing releases over SITEMAP_MAX_RELEASES", log.Data{"language": lang, "release_type": rt.Name(), "max": cfg.SitemapMaxReleases})
+			return all, nil
+		}
+		vp.Offset = offset
+		vp.Page = queryparams.CalculatePageNumber(offset, vp.Limit)
+
+		// collections are never listed, so no access token or collection is passed on
+		releases, err := searchAPI.GetReleases(ctx, "", "", lang, vp.AsBackendQuery())
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, releases.Releases[:min(len(releases.Releases), maxReleases-offset)]...)
+
+		offset += len(releases.Releases)
+		if len(releases.Releases) == 0 || len(releases.Releases) < vp.Limit || offset >= releases.Breakdown.Total {
+			return all, nil
+		}
+	}
+}
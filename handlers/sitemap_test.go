@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	sitesearch "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func sitemapParams(releaseType queryparams.ReleaseType, offset, limit int) url.Values {
+	values := url.Values{}
+	values.Set("limit", strconv.Itoa(limit))
+	values.Set("page", strconv.Itoa(queryparams.CalculatePageNumber(offset, limit)))
+	values.Set("offset", strconv.Itoa(offset))
+	// the newest upcoming release is the soonest, so their order is reversed
+	sort := queryparams.RelDateAsc
+	if releaseType == queryparams.Upcoming {
+		sort = queryparams.RelDateDesc
+	}
+	values.Set("sort", sort.BackendString())
+	values.Set("release-type", releaseType.Name())
+	return values
+}
+
+func TestSitemaps(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := gomock.Any()
+
+	Convey("Given the sitemap endpoints", t, func() {
+		now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+		mockConfig, _ := config.Get()
+		cfg := *mockConfig
+		cfg.Clock = func() time.Time { return now }
+		cfg.DefaultMaximumLimit = 2
+		cfg.SitemapPageSize = 2
+		cfg.SitemapCacheTTL = time.Hour
+		cfg.SitemapMaxReleases = 100
+		cfg.SiteDomain = "ons.gov.uk"
+
+		mockSearchClient := NewMockSearchAPI(mockCtrl)
+		router := mux.NewRouter()
+		sitemaps := NewSitemaps()
+		router.HandleFunc("/releasecalendar/sitemap.xml", SitemapIndex(cfg, mockSearchClient, sitemaps))
+		router.HandleFunc("/releasecalendar/sitemap-{page:[0-9]+}.xml", Sitemap(cfg, mockSearchClient, sitemaps))
+		get := func(path string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "http://localhost:27700"+path, http.NoBody))
+			return w
+		}
+
+		byType := map[queryparams.ReleaseType][]sitesearch.Release{
+			queryparams.Upcoming:  {datedRelease("/releases/release2", "2026-11-01T07:00:00Z")},
+			queryparams.Published: {datedRelease("/releases/release0", "2026-10-01T06:00:00Z"), datedRelease("/releases/release1", "2026-10-05T06:00:00Z")},
+			queryparams.Cancelled: {datedRelease("/releases/release3", "2026-10-03T06:00:00Z")},
+		}
+		expectReleases := func() {
+			for rt, releases := range byType {
+				mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, sitemapParams(rt, 0, 2)).
+					Return(sitesearch.ReleaseResponse{Breakdown: sitesearch.Breakdown{Total: len(releases)}, Releases: releases}, nil)
+			}
+		}
+
+		Convey("When the index and its sitemaps are requested", func() {
+			expectReleases()
+			index := get("/releasecalendar/sitemap.xml")
+			first := get("/releasecalendar/sitemap-1.xml")
+			second := get("/releasecalendar/sitemap-2.xml")
+
+			Convey("Then the index lists a sitemap for each page of releases", func() {
+				So(index.Code, ShouldEqual, http.StatusOK)
+				So(index.Header().Get("Content-Type"), ShouldEqual, "application/xml; charset=utf-8")
+				So(index.Body.String(), ShouldContainSubstring, "<sitemap><loc>https://www.ons.gov.uk/releasecalendar/sitemap-1.xml</loc><lastmod>2026-10-03T06:00:00Z</lastmod></sitemap>")
+				So(index.Body.String(), ShouldContainSubstring, "<loc>https://www.ons.gov.uk/releasecalendar/sitemap-2.xml</loc>")
+			})
+
+			Convey("Then the sitemaps list the release pages of every release type, oldest first, with their Welsh alternates", func() {
+				So(first.Code, ShouldEqual, http.StatusOK)
+				So(first.Body.String(), ShouldContainSubstring, "<loc>https://www.ons.gov.uk/releases/release0</loc><lastmod>2026-10-01T06:00:00Z</lastmod>")
+				So(first.Body.String(), ShouldContainSubstring, `<xhtml:link rel="alternate" hreflang="cy" href="https://cy.ons.gov.uk/releases/release3"></xhtml:link>`)
+				So(first.Body.String(), ShouldNotContainSubstring, "release1")
+				So(second.Body.String(), ShouldContainSubstring, "<loc>https://www.ons.gov.uk/releases/release1</loc>")
+				So(second.Body.String(), ShouldContainSubstring, "<loc>https://www.ons.gov.uk/releases/release2</loc>")
+			})
+
+			Convey("Then a sitemap past the last is not found", func() {
+				So(get("/releasecalendar/sitemap-3.xml").Code, ShouldEqual, http.StatusNotFound)
+				So(get("/releasecalendar/sitemap-0.xml").Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When the sitemaps are requested in a collection", func() {
+			expectReleases()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "http://localhost:27700/releasecalendar/sitemap-2.xml", http.NoBody)
+			So(setRequestHeaders(req), ShouldBeNil)
+			router.ServeHTTP(w, req)
+
+			Convey("Then they list only published content, and are cached for requests outside the collection", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, "release2")
+				So(get("/releasecalendar/sitemap.xml").Code, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When there are more releases than the sitemaps may list", func() {
+			cfg.SitemapMaxReleases = 3
+			sitemaps := NewSitemaps()
+			for rt, releases := range byType {
+				if rt == queryparams.Cancelled {
+					continue
+				}
+				mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, sitemapParams(rt, 0, 2)).
+					Return(sitesearch.ReleaseResponse{Breakdown: sitesearch.Breakdown{Total: len(releases)}, Releases: releases}, nil)
+			}
+			set, err := sitemaps.get(t.Context(), cfg, mockSearchClient, lang)
+
+			Convey("Then the search API is not asked for any more of them", func() {
+				So(err, ShouldBeNil)
+				So(set.sitemaps, ShouldHaveLength, 2)
+				So(string(set.sitemaps[1].Data), ShouldContainSubstring, "release2")
+				So(string(set.sitemaps[1].Data), ShouldNotContainSubstring, "release3")
+			})
+		})
+
+		Convey("When the sitemaps are requested again after the cache has expired", func() {
+			expectReleases()
+			get("/releasecalendar/sitemap.xml")
+			now = now.Add(2 * time.Hour)
+
+			Convey("Then they are rebuilt", func() {
+				expectReleases()
+				So(get("/releasecalendar/sitemap-1.xml").Code, ShouldEqual, http.StatusOK)
+			})
+
+			Convey("Then those built before are served if the search API fails", func() {
+				mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, gomock.Any()).
+					Return(sitesearch.ReleaseResponse{}, errors.New("search API unavailable"))
+				w := get("/releasecalendar/sitemap-2.xml")
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, "release2")
+			})
+		})
+
+		Convey("When the search API fails before any sitemaps are built", func() {
+			mockSearchClient.EXPECT().GetReleases(ctx, "", "", lang, gomock.Any()).
+				Return(sitesearch.ReleaseResponse{}, errors.New("search API unavailable"))
+
+			Convey("Then an error is returned", func() {
+				So(get("/releasecalendar/sitemap.xml").Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
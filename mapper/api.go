@@ -0,0 +1,69 @@
+package mapper
+
+import (
+	"github.com/ONSdigital/dp-api-clients-go/v2/releasecalendar"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	search "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/api"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+)
+
+// CreateAPIReleaseList maps a page of search results onto its v1 API representation
+func CreateAPIReleaseList(params queryparams.ValidatedParams, response search.ReleaseResponse, cfg config.Config) api.ReleaseList {
+	path := cfg.APIPath() + "/releasecalendar"
+
+	totalResults := cfg.DefaultMaximumSearchResults
+	if totalResults > response.Breakdown.Total {
+		totalResults = response.Breakdown.Total
+	}
+	currentPage := queryparams.CalculatePageNumber(params.Offset, params.Limit)
+	totalPages := queryparams.CalculatePageNumber(totalResults-1, params.Limit)
+
+	list := api.ReleaseList{
+		Count:      response.Breakdown.Total,
+		Limit:      params.Limit,
+		Page:       currentPage,
+		TotalPages: totalPages,
+		Breakdown: api.Breakdown{
+			Published:   response.Breakdown.Published,
+			Upcoming:    response.Breakdown.Provisional + response.Breakdown.Confirmed + response.Breakdown.Postponed,
+			Provisional: response.Breakdown.Provisional,
+			Confirmed:   response.Breakdown.Confirmed,
+			Postponed:   response.Breakdown.Postponed,
+			Cancelled:   response.Breakdown.Cancelled,
+			Census:      response.Breakdown.Census,
+		},
+		Links: api.PaginationLinks{
+			Self:  api.Link{HRef: getPageURL(currentPage, params, path)},
+			First: api.Link{HRef: getPageURL(1, params, path)},
+			Last:  api.Link{HRef: getPageURL(totalPages, params, path)},
+		},
+		Items: make([]api.ReleaseSummary, 0, len(response.Releases)),
+	}
+
+	if currentPage < totalPages {
+		list.Links.Next = &api.Link{HRef: getPageURL(currentPage+1, params, path)}
+	}
+	if currentPage > 1 {
+		list.Links.Prev = &api.Link{HRef: getPageURL(currentPage-1, params, path)}
+	}
+
+	for i := range response.Releases {
+		list.Items = append(list.Items, CreateAPIReleaseSummary(response.Releases[i], cfg))
+	}
+
+	return list
+}
+
+// CreateAPIReleaseSummary maps a search result onto its v1 API representation
+func CreateAPIReleaseSummary(release search.Release, cfg config.Config) api.ReleaseSummary {
+	entry := calendarEntryFromRelease(release, "")
+	return api.NewReleaseSummary(entry, cfg.APIPath()+entry.URI, cfg.RoutingPrefix+entry.URI)
+}
+
+// CreateAPIRelease maps a release onto its v1 API representation
+func CreateAPIRelease(release releasecalendar.Release, cfg config.Config) api.Release {
+	return api.NewRelease(mapRelease(release), cfg.APIPath()+release.URI, cfg.RoutingPrefix+release.URI)
+}
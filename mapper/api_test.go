@@ -0,0 +1,106 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/releasecalendar"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	search "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/api"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateAPIReleaseList(t *testing.T) {
+	Convey("Given the second page of search results", t, func() {
+		cfg, _ := config.Get()
+		params := queryparams.ValidatedParams{
+			Limit:        10,
+			Page:         2,
+			Offset:       10,
+			Sort:         queryparams.RelDateDesc,
+			ReleaseTypes: queryparams.NewReleaseTypes(queryparams.Upcoming),
+		}
+		response := search.ReleaseResponse{
+			Breakdown: search.Breakdown{Total: 35, Provisional: 20, Confirmed: 10, Postponed: 5},
+			Releases: []search.Release{{
+				URI:         "/releases/upcoming",
+				Description: search.ReleaseDescription{Title: "Upcoming release", Finalised: true},
+			}},
+		}
+
+		Convey("When it is mapped to the v1 representation", func() {
+			list := CreateAPIReleaseList(params, response, *cfg)
+
+			Convey("Then the counts and pages are correct", func() {
+				So(list.Count, ShouldEqual, 35)
+				So(list.Page, ShouldEqual, 2)
+				So(list.TotalPages, ShouldEqual, 4)
+				So(list.Breakdown.Upcoming, ShouldEqual, 35)
+			})
+
+			Convey("Then links are given to the neighbouring pages", func() {
+				So(list.Links.Self.HRef, ShouldEqual, getPageURL(2, params, "/v1/releasecalendar"))
+				So(list.Links.First.HRef, ShouldEqual, getPageURL(1, params, "/v1/releasecalendar"))
+				So(list.Links.Last.HRef, ShouldEqual, getPageURL(4, params, "/v1/releasecalendar"))
+				So(list.Links.Prev, ShouldResemble, &api.Link{HRef: getPageURL(1, params, "/v1/releasecalendar")})
+				So(list.Links.Next, ShouldResemble, &api.Link{HRef: getPageURL(3, params, "/v1/releasecalendar")})
+			})
+
+			Convey("Then each release links to its v1 and page representations", func() {
+				So(list.Items, ShouldHaveLength, 1)
+				So(list.Items[0].PublicationState, ShouldResemble, api.PublicationState{Type: "upcoming", SubType: "confirmed"})
+				So(list.Items[0].Links.Self.HRef, ShouldEqual, "/v1/releases/upcoming")
+				So(list.Items[0].Links.HTML.HRef, ShouldEqual, "/releases/upcoming")
+			})
+		})
+	})
+
+	Convey("Given a single page of search results", t, func() {
+		cfg, _ := config.Get()
+		params := queryparams.ValidatedParams{Limit: 10, Page: 1, Sort: queryparams.RelDateDesc, ReleaseTypes: queryparams.NewReleaseTypes(queryparams.Published)}
+		response := search.ReleaseResponse{Breakdown: search.Breakdown{Total: 3}}
+
+		Convey("When it is mapped to the v1 representation", func() {
+			list := CreateAPIReleaseList(params, response, *cfg)
+
+			Convey("Then there are no next or previous links and items is empty rather than null", func() {
+				So(list.Links.Next, ShouldBeNil)
+				So(list.Links.Prev, ShouldBeNil)
+				So(list.Items, ShouldNotBeNil)
+				So(list.Items, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestCreateAPIRelease(t *testing.T) {
+	Convey("Given a cancelled release", t, func() {
+		cfg, _ := config.Get()
+		release := releasecalendar.Release{
+			URI: "/releases/cancelled",
+			Description: releasecalendar.ReleaseDescription{
+				Title:              "Cancelled release",
+				Cancelled:          true,
+				CancellationNotice: []string{"no longer required"},
+			},
+			RelatedDocuments: []releasecalendar.Link{{Title: "Bulletin", URI: "/bulletin"}},
+		}
+
+		Convey("When it is mapped to the v1 representation", func() {
+			resp := CreateAPIRelease(release, *cfg)
+
+			Convey("Then the release details and links are included", func() {
+				So(resp.Title, ShouldEqual, "Cancelled release")
+				So(resp.PublicationState.Type, ShouldEqual, "cancelled")
+				So(resp.CancellationNotice, ShouldResemble, []string{"no longer required"})
+				So(resp.RelatedDocuments, ShouldResemble, []api.RelatedLink{{Title: "Bulletin", URI: "/bulletin"}})
+				So(resp.RelatedDatasets, ShouldNotBeNil)
+				So(resp.Links.Self.HRef, ShouldEqual, "/v1/releases/cancelled")
+				So(resp.Links.HTML.HRef, ShouldEqual, "/releases/cancelled")
+			})
+		})
+	})
+}
@@ -0,0 +1,85 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/ONSdigital/dis-design-system-go/v2/helper"
+	coreModel "github.com/ONSdigital/dis-design-system-go/v2/model"
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+)
+
+// GroupsByDay reports whether the entries of a calendar list are grouped under day headings, which is when grouping
+// has not been turned off and they are in release date order
+func GroupsByDay(params queryparams.ValidatedParams) bool {
+	return !params.Ungrouped && params.CanGroupByDay()
+}
+
+// mapDayGroupingOptions returns links to show a list of entries in release date order grouped under day headings or
+// as a single list, keeping the current filters. Lists in any other order cannot be grouped, so have no options.
+func mapDayGroupingOptions(params queryparams.ValidatedParams, path string) []model.ViewOption {
+	if !params.CanGroupByDay() {
+		return nil
+	}
+
+	options := []struct {
+		ungrouped bool
+		localeKey string
+	}{
+		{false, "CalendarGroupByDay"},
+		{true, "CalendarUngrouped"},
+	}
+	groupings := make([]model.ViewOption, 0, len(options))
+	for _, o := range options {
+		vp := params
+		vp.Ungrouped = o.ungrouped
+		groupings = append(groupings, model.ViewOption{
+			Label:     coreModel.Localisation{LocaleKey: o.localeKey, Plural: 1},
+			URL:       path + "?" + vp.AsFrontendQuery().Encode(),
+			IsCurrent: o.ungrouped == params.Ungrouped,
+		})
+	}
+	return groupings
+}
+
+// mapEntriesDays returns a heading for each run of entries released on the same day. Entries without a valid release
+// date are grouped under an empty heading.
+func mapEntriesDays(entries []model.CalendarEntry, lang string) []model.EntriesDay {
+	var days []model.EntriesDay
+	for i := range entries {
+		date, _ := ReleaseDay(entries[i].Description.ReleaseDate)
+		if len(days) > 0 && days[len(days)-1].Date == date {
+			days[len(days)-1].Count++
+			continue
+		}
+
+		days = append(days, model.EntriesDay{
+			Date:    date,
+			Heading: dayHeading(date, lang),
+			Start:   i,
+			Count:   1,
+		})
+	}
+
+	return days
+}
+
+// MarkContinuedDay marks the first day of a page as continued when the release before the page, previousReleaseDate,
+// is on the same day
+func MarkContinuedDay(days []model.EntriesDay, previousReleaseDate string) {
+	if len(days) == 0 || days[0].Date == "" {
+		return
+	}
+	if date, ok := ReleaseDay(previousReleaseDate); ok && date == days[0].Date {
+		days[0].Continued = true
+	}
+}
+
+// dayHeading formats a day, in the format YYYY-MM-DD, as for example "Tuesday 20 October 2026"
+func dayHeading(date, lang string) string {
+	t, err := time.Parse(dayKeyFormat, date)
+	if err != nil {
+		return ""
+	}
+	return helper.Localise("Weekday"+t.Weekday().String(), lang, 1) + " " + localiseDate(t, lang)
+}
@@ -0,0 +1,124 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dis-design-system-go/v2/helper"
+	"github.com/ONSdigital/dp-frontend-release-calendar/mocks"
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGroupsByDay(t *testing.T) {
+	Convey("Lists are grouped by day only when sorted by release date", t, func() {
+		So(GroupsByDay(queryparams.ValidatedParams{Sort: queryparams.RelDateAsc}), ShouldBeTrue)
+		So(GroupsByDay(queryparams.ValidatedParams{Sort: queryparams.RelDateDesc}), ShouldBeTrue)
+		So(GroupsByDay(queryparams.ValidatedParams{Sort: queryparams.TitleAZ}), ShouldBeFalse)
+		So(GroupsByDay(queryparams.ValidatedParams{Sort: queryparams.Relevance}), ShouldBeFalse)
+		So(GroupsByDay(queryparams.ValidatedParams{Sort: queryparams.RelDateDesc, View: queryparams.Month}), ShouldBeFalse)
+	})
+
+	Convey("Lists are not grouped by day when grouping is turned off", t, func() {
+		So(GroupsByDay(queryparams.ValidatedParams{Sort: queryparams.RelDateDesc, Ungrouped: true}), ShouldBeFalse)
+	})
+}
+
+func TestMapDayGroupingOptions(t *testing.T) {
+	Convey("Given a list in release date order", t, func() {
+		params := queryparams.ValidatedParams{
+			Limit:        10,
+			Page:         2,
+			Sort:         queryparams.RelDateDesc,
+			ReleaseTypes: queryparams.NewReleaseTypes(queryparams.Published),
+		}
+
+		Convey("Then it is grouped by day, with a link to the same page as a single list", func() {
+			options := mapDayGroupingOptions(params, "/releasecalendar")
+			So(options, ShouldHaveLength, 2)
+			So(options[0].Label.LocaleKey, ShouldEqual, "CalendarGroupByDay")
+			So(options[0].IsCurrent, ShouldBeTrue)
+			So(options[1].IsCurrent, ShouldBeFalse)
+			So(options[1].URL, ShouldEqual, "/releasecalendar?group-by-day=false&limit=10&page=2&release-type=type-published&sort=date-newest")
+		})
+
+		Convey("When grouping is turned off", func() {
+			params.Ungrouped = true
+			options := mapDayGroupingOptions(params, "/releasecalendar")
+
+			Convey("Then it is a single list, with a link to group it by day", func() {
+				So(options[1].IsCurrent, ShouldBeTrue)
+				So(options[0].URL, ShouldEqual, "/releasecalendar?limit=10&page=2&release-type=type-published&sort=date-newest")
+			})
+		})
+	})
+
+	Convey("Given a list sorted by title", t, func() {
+		params := queryparams.ValidatedParams{Sort: queryparams.TitleAZ}
+
+		Convey("Then there are no options, as it cannot be grouped", func() {
+			So(mapDayGroupingOptions(params, "/releasecalendar"), ShouldBeNil)
+		})
+	})
+}
+
+func TestMapEntriesDays(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+	Convey("Given a page of entries in release date order", t, func() {
+		entries := []model.CalendarEntry{
+			entryAt("/releases/a", "2026-10-20T06:00:00Z"),
+			entryAt("/releases/b", "2026-10-20T22:59:00Z"),
+			entryAt("/releases/c", "2026-10-20T23:30:00Z"),
+			entryAt("/releases/d", "2026-10-26T09:30:00Z"),
+		}
+
+		Convey("When they are grouped in English", func() {
+			days := mapEntriesDays(entries, "en")
+
+			Convey("Then there is a heading for each day in Europe/London", func() {
+				So(days, ShouldResemble, []model.EntriesDay{
+					{Date: "2026-10-20", Heading: "Tuesday 20 October 2026", Start: 0, Count: 2},
+					{Date: "2026-10-21", Heading: "Wednesday 21 October 2026", Start: 2, Count: 1},
+					{Date: "2026-10-26", Heading: "Monday 26 October 2026", Start: 3, Count: 1},
+				})
+			})
+		})
+
+		Convey("When they are grouped in Welsh", func() {
+			days := mapEntriesDays(entries, "cy")
+
+			Convey("Then the headings are in Welsh", func() {
+				So(days[0].Heading, ShouldEqual, "Dydd Mawrth 20 Hydref 2026")
+				So(days[2].Heading, ShouldEqual, "Dydd Llun 26 Hydref 2026")
+			})
+		})
+
+		Convey("When the release before the page is on the first day", func() {
+			days := mapEntriesDays(entries, "en")
+			MarkContinuedDay(days, "2026-10-19T23:15:00Z")
+
+			Convey("Then the first day is continued", func() {
+				So(days[0].Continued, ShouldBeTrue)
+				So(days[1].Continued, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the release before the page is on an earlier day", func() {
+			days := mapEntriesDays(entries, "en")
+			MarkContinuedDay(days, "2026-10-19T22:15:00Z")
+
+			Convey("Then the first day is not continued", func() {
+				So(days[0].Continued, ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Entries without a valid release date are grouped without a heading", t, func() {
+		days := mapEntriesDays([]model.CalendarEntry{entryAt("/releases/a", ""), entryAt("/releases/b", "")}, "en")
+		So(days, ShouldResemble, []model.EntriesDay{{Start: 0, Count: 2}})
+
+		MarkContinuedDay(days, "")
+		So(days[0].Continued, ShouldBeFalse)
+	})
+}
@@ -0,0 +1,32 @@
+package mapper
+
+import (
+	"strings"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	search "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+)
+
+// CreateExportRow maps a search API release onto a row of a calendar export. The search API does not
+// return cancellation notices, so these are supplied separately by the caller for cancelled releases.
+func CreateExportRow(release search.Release, cancellationNotice []string, uriPrefix string) model.ExportRow {
+	release.Highlight = nil
+	entry := calendarEntryFromRelease(release, uriPrefix)
+
+	row := model.ExportRow{
+		Title:              entry.Description.Title,
+		URI:                entry.URI,
+		ReleaseDate:        entry.Description.ReleaseDate,
+		ProvisionalDate:    entry.Description.ProvisionalDate,
+		PublicationState:   entry.PublicationState,
+		Census:             release.Description.Census,
+		CancellationNotice: strings.Join(cancellationNotice, " "),
+	}
+
+	if totalDateChanges := len(entry.DateChanges); totalDateChanges > 0 {
+		row.PostponementReason = entry.DateChanges[totalDateChanges-1].ChangeNotice
+	}
+
+	return row
+}
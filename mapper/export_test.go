@@ -0,0 +1,62 @@
+package mapper
+
+import (
+	"testing"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	search "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateExportRow(t *testing.T) {
+	Convey("Given a postponed census release with highlighting", t, func() {
+		release := search.Release{
+			URI: "/releases/census",
+			DateChanges: []search.ReleaseDateChange{
+				{ChangeNotice: "first change", Date: "2026-01-01T07:00:00Z"},
+				{ChangeNotice: "latest change", Date: "2026-02-01T07:00:00Z"},
+			},
+			Description: search.ReleaseDescription{
+				Title:           "Census release",
+				ReleaseDate:     "2026-03-01T07:00:00Z",
+				ProvisionalDate: "March 2026",
+				Finalised:       true,
+				Census:          true,
+			},
+			Highlight: &search.Highlight{Title: "<em class=\"ons-highlight\">Census</em> release"},
+		}
+
+		Convey("When it is mapped to an export row", func() {
+			row := CreateExportRow(release, nil, "/prefix")
+
+			Convey("Then the row contains the release details without highlighting", func() {
+				So(row, ShouldResemble, model.ExportRow{
+					Title:              "Census release",
+					URI:                "/prefix/releases/census",
+					ReleaseDate:        "2026-03-01T07:00:00Z",
+					ProvisionalDate:    "March 2026",
+					PublicationState:   model.PublicationState{Type: "upcoming", SubType: "postponed"},
+					Census:             true,
+					PostponementReason: "latest change",
+				})
+			})
+		})
+	})
+
+	Convey("Given a cancelled release with a cancellation notice", t, func() {
+		release := search.Release{
+			URI:         "/releases/cancelled",
+			Description: search.ReleaseDescription{Title: "Cancelled release", Cancelled: true},
+		}
+
+		Convey("When it is mapped to an export row", func() {
+			row := CreateExportRow(release, []string{"Cancelled due to", "data quality"}, "")
+
+			Convey("Then the notice paragraphs are joined", func() {
+				So(row.PublicationState.Type, ShouldEqual, "cancelled")
+				So(row.CancellationNotice, ShouldEqual, "Cancelled due to data quality")
+			})
+		})
+	})
+}
@@ -0,0 +1,149 @@
+package mapper
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ONSdigital/dis-design-system-go/v2/helper"
+	coreModel "github.com/ONSdigital/dis-design-system-go/v2/model"
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+)
+
+const dayKeyFormat = "2006-01-02"
+
+var weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+
+// CreateCalendarGrid groups calendar entries into the days of the month or week selected by the params. now is used
+// to mark today in the grid.
+func CreateCalendarGrid(params queryparams.ValidatedParams, entries []model.CalendarEntry, path, lang string, now time.Time) *model.CalendarGrid {
+	anchor := localMidnight(params.ViewDate.Time())
+	start, end := params.View.Window(anchor)
+	gridStart, _ := queryparams.Week.Window(start)
+	_, gridEnd := queryparams.Week.Window(end)
+
+	byDay := groupByDay(entries)
+	today := now.In(queryparams.London).Format(dayKeyFormat)
+
+	grid := &model.CalendarGrid{
+		View:     params.View.String(),
+		Title:    gridTitle(params.View, start, lang),
+		Previous: gridLink(params, params.View.Step(anchor, -1), path, lang),
+		Next:     gridLink(params, params.View.Step(anchor, 1), path, lang),
+	}
+	for _, wd := range weekdays {
+		grid.Weekdays = append(grid.Weekdays, helper.Localise("Weekday"+wd.String(), lang, 1))
+	}
+
+	var week model.CalendarWeek
+	for d := gridStart; !d.After(gridEnd); d = d.AddDate(0, 0, 1) {
+		key := d.Format(dayKeyFormat)
+		day := model.CalendarDay{
+			Date:     key,
+			Day:      d.Day(),
+			InPeriod: !d.Before(start) && !d.After(end),
+			IsToday:  key == today,
+		}
+		if day.InPeriod {
+			day.Entries = byDay[key]
+		}
+		week.Days = append(week.Days, day)
+
+		if len(week.Days) == len(weekdays) {
+			grid.Weeks = append(grid.Weeks, week)
+			week = model.CalendarWeek{}
+		}
+	}
+
+	return grid
+}
+
+// ReleaseDay returns the day a release date falls on in Europe/London, in the format YYYY-MM-DD
+func ReleaseDay(releaseDate string) (string, bool) {
+	t, err := time.Parse(time.RFC3339, releaseDate)
+	if err != nil {
+		return "", false
+	}
+	return t.In(queryparams.London).Format(dayKeyFormat), true
+}
+
+// groupByDay groups entries by the day they are released on, ordering each day's entries by release time
+func groupByDay(entries []model.CalendarEntry) map[string][]model.CalendarEntry {
+	byDay := make(map[string][]model.CalendarEntry)
+	for i := range entries {
+		if day, ok := ReleaseDay(entries[i].Description.ReleaseDate); ok {
+			byDay[day] = append(byDay[day], entries[i])
+		}
+	}
+
+	for _, dayEntries := range byDay {
+		sort.SliceStable(dayEntries, func(i, j int) bool {
+			ti, _ := time.Parse(time.RFC3339, dayEntries[i].Description.ReleaseDate)
+			tj, _ := time.Parse(time.RFC3339, dayEntries[j].Description.ReleaseDate)
+			return ti.Before(tj)
+		})
+	}
+
+	return byDay
+}
+
+// localMidnight returns the start of the date of a queryparams date, which is held as midnight UTC, in Europe/London
+func localMidnight(date time.Time) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, queryparams.London)
+}
+
+func gridTitle(view queryparams.View, start time.Time, lang string) string {
+	if view == queryparams.Week {
+		return helper.Localise("CalendarGridWeekTitle", lang, 1, localiseDate(start, lang))
+	}
+	return localiseMonth(start.Month(), lang) + " " + strconv.Itoa(start.Year())
+}
+
+func gridLink(params queryparams.ValidatedParams, date time.Time, path, lang string) model.GridLink {
+	params.ViewDate = queryparams.DateFromTime(time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC))
+	query := params.AsFrontendQuery()
+	query.Del(queryparams.Page)
+
+	return model.GridLink{
+		Label: gridTitle(params.View, date, lang),
+		URL:   path + "?" + query.Encode(),
+	}
+}
+
+// mapViewOptions returns links to show the calendar as a list or grid, keeping the current filters
+func mapViewOptions(params queryparams.ValidatedParams, path string) []model.ViewOption {
+	views := []struct {
+		view      queryparams.View
+		localeKey string
+	}{
+		{queryparams.List, "CalendarViewList"},
+		{queryparams.Month, "CalendarViewMonth"},
+		{queryparams.Week, "CalendarViewWeek"},
+	}
+
+	options := make([]model.ViewOption, 0, len(views))
+	for _, v := range views {
+		vp := params
+		vp.View = v.view
+		query := vp.AsFrontendQuery()
+		query.Del(queryparams.Page)
+		options = append(options, model.ViewOption{
+			Label:     coreModel.Localisation{LocaleKey: v.localeKey, Plural: 1},
+			URL:       path + "?" + query.Encode(),
+			IsCurrent: v.view == params.View,
+		})
+	}
+
+	return options
+}
+
+func localiseMonth(m time.Month, lang string) string {
+	return helper.Localise("Month"+m.String(), lang, 1)
+}
+
+// localiseDate formats a date as, for example, "12 October 2026"
+func localiseDate(t time.Time, lang string) string {
+	return strconv.Itoa(t.Day()) + " " + localiseMonth(t.Month(), lang) + " " + strconv.Itoa(t.Year())
+}
@@ -0,0 +1,183 @@
+package mapper
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-design-system-go/v2/helper"
+	"github.com/ONSdigital/dp-frontend-release-calendar/mocks"
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+	"github.com/ONSdigital/dp-frontend-release-calendar/queryparams"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func entryAt(uri, releaseDate string) model.CalendarEntry {
+	return model.CalendarEntry{URI: uri, Description: model.ReleaseDescription{ReleaseDate: releaseDate}}
+}
+
+func gridDay(grid *model.CalendarGrid, date string) model.CalendarDay {
+	for _, week := range grid.Weeks {
+		for _, day := range week.Days {
+			if day.Date == date {
+				return day
+			}
+		}
+	}
+	return model.CalendarDay{}
+}
+
+func TestReleaseDay(t *testing.T) {
+	Convey("Given release dates either side of midnight", t, func() {
+		testcases := []struct {
+			description string
+			releaseDate string
+			exDay       string
+		}{
+			{"a summer release late in the UTC day is on the next local day", "2026-07-01T23:30:00Z", "2026-07-02"},
+			{"a winter release late in the UTC day is on the same day", "2026-12-01T23:30:00Z", "2026-12-01"},
+			{"a release just before the clocks go forward is on that day", "2026-03-29T00:30:00Z", "2026-03-29"},
+			{"a release just after the clocks go back is on that day", "2026-10-24T23:30:00Z", "2026-10-25"},
+			{"an offset release date is converted to London time", "2026-06-01T07:00:00+09:00", "2026-05-31"},
+		}
+
+		for _, tc := range testcases {
+			Convey(tc.description, func() {
+				day, ok := ReleaseDay(tc.releaseDate)
+				So(ok, ShouldBeTrue)
+				So(day, ShouldEqual, tc.exDay)
+			})
+		}
+	})
+
+	Convey("An invalid release date has no day", t, func() {
+		_, ok := ReleaseDay("October 2026")
+		So(ok, ShouldBeFalse)
+	})
+}
+
+func TestCreateCalendarGrid(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	Convey("Given a month view of October 2026 with filters", t, func() {
+		params := queryparams.ValidatedParams{
+			Limit:        10,
+			Page:         3,
+			Keywords:     "gdp",
+			Sort:         queryparams.RelDateDesc,
+			ReleaseTypes: queryparams.NewReleaseTypes(queryparams.Published),
+			View:         queryparams.Month,
+			ViewDate:     queryparams.MustParseDate("2026-10-18"),
+		}
+		entries := []model.CalendarEntry{
+			entryAt("/releases/late", "2026-10-01T09:30:00Z"),
+			entryAt("/releases/early", "2026-10-01T06:00:00Z"),
+			entryAt("/releases/after-clocks-change", "2026-10-25T23:30:00Z"),
+			entryAt("/releases/before-clocks-change", "2026-10-24T23:30:00Z"),
+		}
+
+		Convey("When the grid is created", func() {
+			grid := CreateCalendarGrid(params, entries, "/releasecalendar", "en", now)
+
+			Convey("Then it has complete weeks from Monday to Sunday around the month", func() {
+				So(grid.Title, ShouldEqual, "October 2026")
+				So(grid.Weekdays, ShouldResemble, []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"})
+				So(grid.Weeks, ShouldHaveLength, 5)
+				So(grid.Weeks[0].Days[0].Date, ShouldEqual, "2026-09-28")
+				So(grid.Weeks[0].Days[0].InPeriod, ShouldBeFalse)
+				So(grid.Weeks[0].Days[3].Date, ShouldEqual, "2026-10-01")
+				So(grid.Weeks[0].Days[3].InPeriod, ShouldBeTrue)
+				So(grid.Weeks[4].Days[6].Date, ShouldEqual, "2026-11-01")
+				for _, week := range grid.Weeks {
+					So(week.Days, ShouldHaveLength, 7)
+				}
+			})
+
+			Convey("Then each day holds its releases in time order", func() {
+				day := gridDay(grid, "2026-10-01")
+				So(day.Entries, ShouldHaveLength, 2)
+				So(day.Entries[0].URI, ShouldEqual, "/releases/early")
+				So(day.Entries[1].URI, ShouldEqual, "/releases/late")
+			})
+
+			Convey("Then releases around the end of summer time are on their local day", func() {
+				So(gridDay(grid, "2026-10-25").Entries, ShouldResemble, []model.CalendarEntry{entries[3], entries[2]})
+				So(gridDay(grid, "2026-10-25").Day, ShouldEqual, 25)
+				So(gridDay(grid, "2026-10-24").Entries, ShouldBeEmpty)
+				So(gridDay(grid, "2026-10-26").Entries, ShouldBeEmpty)
+			})
+
+			Convey("Then today is marked", func() {
+				So(gridDay(grid, "2026-10-18").IsToday, ShouldBeTrue)
+				So(gridDay(grid, "2026-10-19").IsToday, ShouldBeFalse)
+			})
+
+			Convey("Then the previous and next months keep the filters", func() {
+				So(grid.Previous.Label, ShouldEqual, "September 2026")
+				So(grid.Next.Label, ShouldEqual, "November 2026")
+
+				next, err := url.Parse(grid.Next.URL)
+				So(err, ShouldBeNil)
+				So(next.Path, ShouldEqual, "/releasecalendar")
+				So(next.Query().Get("date"), ShouldEqual, "2026-11-01")
+				So(next.Query().Get("view"), ShouldEqual, "month")
+				So(next.Query().Get("keywords"), ShouldEqual, "gdp")
+				So(next.Query().Has("page"), ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a Welsh week view of the week the clocks go back", t, func() {
+		params := queryparams.ValidatedParams{
+			Sort:         queryparams.RelDateDesc,
+			ReleaseTypes: queryparams.NewReleaseTypes(queryparams.Upcoming),
+			View:         queryparams.Week,
+			ViewDate:     queryparams.MustParseDate("2026-10-21"),
+		}
+
+		Convey("When the grid is created", func() {
+			grid := CreateCalendarGrid(params, nil, "/releasecalendar", "cy", now)
+
+			Convey("Then it has a single week of seven consecutive days", func() {
+				So(grid.Title, ShouldEqual, "Wythnos yn dechrau 19 Hydref 2026")
+				So(grid.Weeks, ShouldHaveLength, 1)
+				dates := make([]string, 0, 7)
+				for _, day := range grid.Weeks[0].Days {
+					So(day.InPeriod, ShouldBeTrue)
+					dates = append(dates, day.Date)
+				}
+				So(dates, ShouldResemble, []string{"2026-10-19", "2026-10-20", "2026-10-21", "2026-10-22", "2026-10-23", "2026-10-24", "2026-10-25"})
+			})
+
+			Convey("Then the previous and next weeks are linked", func() {
+				So(grid.Previous.Label, ShouldEqual, "Wythnos yn dechrau 12 Hydref 2026")
+				So(grid.Next.Label, ShouldEqual, "Wythnos yn dechrau 26 Hydref 2026")
+			})
+		})
+	})
+}
+
+func TestMapViewOptions(t *testing.T) {
+	Convey("Given the month view", t, func() {
+		params := queryparams.ValidatedParams{
+			Limit:        10,
+			Page:         2,
+			Sort:         queryparams.RelDateDesc,
+			ReleaseTypes: queryparams.NewReleaseTypes(queryparams.Published),
+			View:         queryparams.Month,
+			ViewDate:     queryparams.MustParseDate("2026-10-18"),
+		}
+
+		Convey("When the view options are mapped", func() {
+			options := mapViewOptions(params, "/releasecalendar")
+
+			Convey("Then the month is current and the others link to the same filters", func() {
+				So(options, ShouldHaveLength, 3)
+				So(options[0].URL, ShouldEqual, "/releasecalendar?limit=10&release-type=type-published&sort=date-newest")
+				So(options[1].IsCurrent, ShouldBeTrue)
+				So(options[2].URL, ShouldEqual, "/releasecalendar?date=2026-10-18&limit=10&release-type=type-published&sort=date-newest&view=week")
+			})
+		})
+	})
+}
@@ -3,6 +3,7 @@ package mapper
 import (
 	"fmt"
 	"html/template"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -217,8 +218,48 @@ func createPreGTMJavaScript(title string, description model.ReleaseDescription)
 }
 
 func CreateRelease(cfg config.Config, basePage coreModel.Page, release releasecalendar.Release, lang, path, serviceMessage string, emergencyBannerContent zebedee.EmergencyBanner) model.Release {
+	result := mapRelease(release)
+	result.Page = basePage
+	result.FeatureFlags.IsPublishing = cfg.IsPublishing
+	result.Language = lang
+	result.Type = "releaseCalendar"
+	result.ServiceMessage = serviceMessage
+	result.EmergencyBanner = mapEmergencyBanner(emergencyBannerContent)
+
+	result.BetaBannerEnabled = true
+	result.Metadata.Title = release.Description.Title
+	result.URI = release.URI
+	result.AboutTheData = result.Description.NationalStatistic || result.Description.WelshStatistic || result.Description.Census2021
+
+	result.Breadcrumb = mapBreadcrumbTrail(result.Description, result.Language, path)
+
+	result.TableOfContents = createTableOfContents(
+		result.Description,
+		result.RelatedDocuments,
+		result.RelatedDatasets,
+		result.RelatedAPIDatasets,
+		result.DateChanges,
+		result.AboutTheData,
+		result.RelatedAPIDatasets,
+		result.RelatedMethodology,
+		result.RelatedMethodologyArticle,
+		result.Links,
+		result.Markdown,
+	)
+	result.PreGTMJavaScript = createPreGTMJavaScript(result.Metadata.Title, result.Description)
+	result.StructuredData = createStructuredData(result, cfg)
+
+	if !result.Description.Finalised && result.Description.ProvisionalDate == "" {
+		result.Description.ProvisionalDate = helper.DateTimeOnsDatePatternFormat(result.Description.ReleaseDate, result.Language)
+	}
+	result.Metadata.Description = createReleaseDescription(result)
+	result.Social = createReleaseSocialMetadata(result, cfg)
+	return result
+}
+
+// mapRelease maps the content of a release, independent of the page it is rendered on
+func mapRelease(release releasecalendar.Release) model.Release {
 	result := model.Release{
-		Page:     basePage,
 		Markdown: convertMarkdownToHTML(release.Markdown),
 		Description: model.ReleaseDescription{
 			Title:   release.Description.Title,
@@ -240,11 +281,7 @@ func CreateRelease(cfg config.Config, basePage coreModel.Page, release releaseca
 			ProvisionalDate:    release.Description.ProvisionalDate,
 		},
 	}
-	result.FeatureFlags.IsPublishing = cfg.IsPublishing
-	result.Language = lang
-	result.Type = "releaseCalendar"
-	result.ServiceMessage = serviceMessage
-	result.EmergencyBanner = mapEmergencyBanner(emergencyBannerContent)
+	result.URI = release.URI
 	result.RelatedDatasets = mapLink(release.RelatedDatasets)
 	result.RelatedAPIDatasets = mapLink(release.RelatedAPIDatasets)
 	result.RelatedDocuments = mapLink(release.RelatedDocuments)
@@ -262,31 +299,6 @@ func CreateRelease(cfg config.Config, basePage coreModel.Page, release releaseca
 
 	result.PublicationState = GetPublicationState(result.Description, result.DateChanges)
 
-	result.BetaBannerEnabled = true
-	result.Metadata.Title = release.Description.Title
-	result.URI = release.URI
-	result.AboutTheData = result.Description.NationalStatistic || result.Description.WelshStatistic || result.Description.Census2021
-
-	result.Breadcrumb = mapBreadcrumbTrail(result.Description, result.Language, path)
-
-	result.TableOfContents = createTableOfContents(
-		result.Description,
-		result.RelatedDocuments,
-		result.RelatedDatasets,
-		result.RelatedAPIDatasets,
-		result.DateChanges,
-		result.AboutTheData,
-		result.RelatedAPIDatasets,
-		result.RelatedMethodology,
-		result.RelatedMethodologyArticle,
-		result.Links,
-		result.Markdown,
-	)
-	result.PreGTMJavaScript = createPreGTMJavaScript(result.Metadata.Title, result.Description)
-
-	if !result.Description.Finalised && result.Description.ProvisionalDate == "" {
-		result.Description.ProvisionalDate = helper.DateTimeOnsDatePatternFormat(result.Description.ReleaseDate, result.Language)
-	}
 	return result
 }
 
@@ -345,6 +357,8 @@ func CreateReleaseCalendar(basePage coreModel.Page, params queryparams.Validated
 	calendar.ServiceMessage = serviceMessage
 	calendar.EmergencyBanner = mapEmergencyBanner(emergencyBannerContent)
 	calendar.Metadata.Title = helper.Localise("ReleaseCalendarPageTitle", calendar.Language, 1)
+	calendar.Metadata.Description = helper.Localise("ReleaseCalendarPageDescription", calendar.Language, 1)
+	calendar.Social = createCalendarSocialMetadata(calendar, cfg)
 	calendar.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
 	calendar.KeywordSearch = coreModel.CompactSearch{
 		ElementId: "keyword-search",
@@ -356,11 +370,15 @@ func CreateReleaseCalendar(basePage coreModel.Page, params queryparams.Validated
 		},
 		SearchTerm: params.Keywords,
 	}
+	calendar.KeywordChips = mapKeywordChips(params, cfg.CalendarPath())
 
 	calendar.Sort = model.Sort{
 		Mode:    params.Sort.String(),
 		Options: mapSortOptions(params),
 	}
+	calendar.Views = mapViewOptions(params, cfg.CalendarPath())
+	calendar.DayGrouping = mapDayGroupingOptions(params, cfg.CalendarPath())
+	calendar.DatePresets = mapDatePresets(params)
 
 	itemsPerPage := params.Limit
 
@@ -373,11 +391,12 @@ func CreateReleaseCalendar(basePage coreModel.Page, params queryparams.Validated
 	calendar.Pagination.TotalPages = queryparams.CalculatePageNumber(totalResults-1, itemsPerPage)
 	calendar.Pagination.CurrentPage = currentPage
 	calendar.Pagination.Limit = itemsPerPage
-	calendar.Pagination.PagesToDisplay = getPagesToDisplay(params, cfg.CalendarPath(), calendar.Pagination.TotalPages, defaultWindowSize)
+	calendar.Pagination.PagesToDisplay = getPagesToDisplay(params, cfg.CalendarPath(), calendar.Pagination.TotalPages, cfg.PaginationWindowSize)
 	calendar.Pagination.FirstAndLastPages = getFirstAndLastPages(params, cfg.CalendarPath(), calendar.Pagination.TotalPages)
-	calendar.Pagination.LimitOptions = []int{10, 25}
+	calendar.Pagination.LimitOptions = cfg.LimitOptions
 	calendar.TotalSearchPosition = getTotalSearchPosition(currentPage, itemsPerPage)
 	calendar.RSSLink = fmt.Sprintf("releasecalendar?rss&%s", params.AsFrontendQuery().Encode())
+	calendar.ICSLink = getICSLink(params, cfg.ICSPath())
 
 	if currentPage > calendar.Pagination.TotalPages {
 		validationErrs = append(validationErrs, coreModel.ErrorItem{
@@ -393,6 +412,9 @@ func CreateReleaseCalendar(basePage coreModel.Page, params queryparams.Validated
 	}
 
 	calendar.Entries.Count = response.Breakdown.Total
+	if GroupsByDay(params) {
+		calendar.Entries.Days = mapEntriesDays(calendar.Entries.Items, lang)
+	}
 	calendar.ReleaseTypes = mapReleases(params, response, calendar.Language)
 
 	var fdErrDescription, tdErrDescription []coreModel.Localisation
@@ -561,8 +583,6 @@ func CreateReleaseCalendar(basePage coreModel.Page, params queryparams.Validated
 // - (1) 2 3 at the start of the page range
 // - 8 9 (10) at the end of the page range
 // - 1 ... 5 (6) 7 ... 10 in the middle of the page range
-const defaultWindowSize = 5
-
 func getPagesToDisplay(params queryparams.ValidatedParams, path string, totalPages, windowSize int) []coreModel.PageToDisplay {
 	start, end := getWindowStartEndPage(params.Page, totalPages, windowSize)
 
@@ -634,6 +654,18 @@ func getPageURL(page int, params queryparams.ValidatedParams, path string) (page
 	return path + "?" + query.Encode()
 }
 
+// CreateLoadMore returns the link to the page of the calendar list after the one described by params, or nil when
+// that page is beyond the pages that can be requested
+func CreateLoadMore(params queryparams.ValidatedParams, cfg config.Config) *model.LoadMore {
+	next := params
+	next.Page++
+	if next.Page > queryparams.MaximumPage(cfg.DefaultMaximumSearchResults, next.Limit) {
+		return nil
+	}
+
+	return &model.LoadMore{URL: cfg.CalendarPath() + "?" + next.AsFrontendQuery().Encode()}
+}
+
 func getWindowOffset(windowSize int) int {
 	if windowSize%2 == 0 {
 		return (windowSize / 2) - 1
@@ -682,7 +714,6 @@ func dateChanges(changes []search.ReleaseDateChange) []model.DateChange {
 }
 
 func mapReleases(params queryparams.ValidatedParams, response search.ReleaseResponse, language string) map[string]model.ReleaseType {
-	checkType := func(given, want queryparams.ReleaseType) bool { return given == want }
 	generateLabel := func(localeKey, language string, plural, count int) string {
 		if count > 0 {
 			return fmt.Sprintf("%s (%d)", helper.Localise(localeKey, language, plural), count)
@@ -698,7 +729,7 @@ func mapReleases(params queryparams.ValidatedParams, response search.ReleaseResp
 				Text: generateLabel("FilterReleaseTypePublished", language, 1, response.Breakdown.Published),
 			},
 			Language:  language,
-			IsChecked: checkType(params.ReleaseType, queryparams.Published),
+			IsChecked: params.ReleaseTypes.Has(queryparams.Published),
 			Count:     response.Breakdown.Published,
 		},
 		"type-upcoming": {
@@ -709,8 +740,43 @@ func mapReleases(params queryparams.ValidatedParams, response search.ReleaseResp
 				Text: generateLabel("FilterReleaseTypeUpcoming", language, 1, response.Breakdown.Provisional+response.Breakdown.Confirmed+response.Breakdown.Postponed),
 			},
 			Language:  language,
-			IsChecked: checkType(params.ReleaseType, queryparams.Upcoming),
+			IsChecked: params.ReleaseTypes.Has(queryparams.Upcoming),
 			Count:     response.Breakdown.Provisional + response.Breakdown.Confirmed + response.Breakdown.Postponed,
+			SubTypes: map[string]model.ReleaseType{
+				"subtype-provisional": {
+					Name:  queryparams.Provisional.Name(),
+					Value: "true",
+					ID:    "release-type-provisional",
+					Label: coreModel.Localisation{
+						Text: generateLabel("FilterReleaseTypeProvisional", language, 1, response.Breakdown.Provisional),
+					},
+					Language:  language,
+					IsChecked: params.Provisional,
+					Count:     response.Breakdown.Provisional,
+				},
+				"subtype-confirmed": {
+					Name:  queryparams.Confirmed.Name(),
+					Value: "true",
+					ID:    "release-type-confirmed",
+					Label: coreModel.Localisation{
+						Text: generateLabel("FilterReleaseTypeConfirmed", language, 1, response.Breakdown.Confirmed),
+					},
+					Language:  language,
+					IsChecked: params.Confirmed,
+					Count:     response.Breakdown.Confirmed,
+				},
+				"subtype-postponed": {
+					Name:  queryparams.Postponed.Name(),
+					Value: "true",
+					ID:    "release-type-postponed",
+					Label: coreModel.Localisation{
+						Text: generateLabel("FilterReleaseTypePostponed", language, 1, response.Breakdown.Postponed),
+					},
+					Language:  language,
+					IsChecked: params.Postponed,
+					Count:     response.Breakdown.Postponed,
+				},
+			},
 		},
 		"type-cancelled": {
 			Name:  "release-type",
@@ -720,7 +786,7 @@ func mapReleases(params queryparams.ValidatedParams, response search.ReleaseResp
 				Text: generateLabel("FilterReleaseTypeCancelled", language, 1, response.Breakdown.Cancelled),
 			},
 			Language:  language,
-			IsChecked: checkType(params.ReleaseType, queryparams.Cancelled),
+			IsChecked: params.ReleaseTypes.Has(queryparams.Cancelled),
 			Count:     response.Breakdown.Cancelled,
 		},
 		"type-census": {
@@ -774,6 +840,76 @@ func mapSortOptions(params queryparams.ValidatedParams) []model.SortOption {
 	}
 }
 
+var datePresetLocaleKeys = map[queryparams.DatePreset]string{
+	queryparams.Today:      "DatePresetToday",
+	queryparams.Tomorrow:   "DatePresetTomorrow",
+	queryparams.ThisWeek:   "DatePresetThisWeek",
+	queryparams.NextWeek:   "DatePresetNextWeek",
+	queryparams.ThisMonth:  "DatePresetThisMonth",
+	queryparams.Next30Days: "DatePresetNext30Days",
+	queryparams.Last7Days:  "DatePresetLast7Days",
+}
+
+func mapDatePresets(params queryparams.ValidatedParams) []model.DatePresetOption {
+	options := []model.DatePresetOption{
+		{
+			ID:        "date-range-custom",
+			Label:     coreModel.Localisation{LocaleKey: "DatePresetCustom", Plural: 1},
+			IsChecked: params.DatePreset == queryparams.NoDatePreset,
+		},
+	}
+	for _, p := range queryparams.DatePresets {
+		options = append(options, model.DatePresetOption{
+			ID:        "date-range-" + p.String(),
+			Value:     p.String(),
+			Label:     coreModel.Localisation{LocaleKey: datePresetLocaleKeys[p], Plural: 1},
+			IsChecked: params.DatePreset == p,
+		})
+	}
+
+	return options
+}
+
+// mapKeywordChips returns a chip for each term of the keyword query, linking to the first page of results without it
+func mapKeywordChips(params queryparams.ValidatedParams, path string) []model.KeywordChip {
+	terms := queryparams.ParseKeywords(params.Keywords)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	chips := make([]model.KeywordChip, 0, len(terms))
+	for i := range terms {
+		vp := params
+		vp.Keywords = terms.Without(i).String()
+		query := vp.AsFrontendQuery()
+		query.Del(queryparams.Page)
+		chips = append(chips, model.KeywordChip{
+			Label:     terms[i].String(),
+			RemoveURL: path + "?" + query.Encode(),
+		})
+	}
+
+	return chips
+}
+
+// getICSLink returns the link to the calendar of upcoming releases, scoped by the same keywords and census filter as
+// the RSS feed. The calendar always covers the upcoming releases of the next three months, so
+// the dates, release types and sort order being viewed are not carried.
+func getICSLink(params queryparams.ValidatedParams, path string) string {
+	query := make(url.Values)
+	if params.Keywords != "" {
+		query.Set(queryparams.Keywords, params.Keywords)
+	}
+	if params.Census {
+		query.Set(queryparams.Census, strconv.FormatBool(params.Census))
+	}
+
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
 func convertMarkdownToHTML(markdowns []string) []string {
 	markdownHTML := make([]string, 0, 1)
 	for _, markdown := range markdowns {
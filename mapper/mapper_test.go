@@ -191,6 +191,10 @@ func TestUnitMapper(t *testing.T) {
 			So(release.PublicationState, ShouldResemble, model.PublicationState{
 				Type: "cancelled",
 			})
+			So(string(release.StructuredData), ShouldContainSubstring, `"eventStatus":"https://schema.org/EventCancelled"`)
+			So(release.Metadata.Description, ShouldStartWith, "Canslwyd. Dyddiad y datganiad: ")
+			So(release.Social.Description, ShouldEqual, release.Metadata.Description)
+			So(release.Social.Locale, ShouldEqual, "cy_GB")
 		})
 	})
 }
@@ -274,17 +278,17 @@ func TestReleaseCalendarMapper(t *testing.T) {
 		}
 
 		params := queryparams.ValidatedParams{
-			Limit:       5,
-			Offset:      0,
-			Page:        1,
-			AfterDate:   queryparams.Date{},
-			BeforeDate:  queryparams.Date{},
-			Keywords:    "everything",
-			Sort:        queryparams.RelDateAsc,
-			ReleaseType: queryparams.Upcoming,
+			Limit:        5,
+			Offset:       0,
+			Page:         1,
+			AfterDate:    queryparams.Date{},
+			BeforeDate:   queryparams.Date{},
+			Keywords:     "everything",
+			Sort:         queryparams.RelDateAsc,
+			ReleaseTypes: queryparams.NewReleaseTypes(queryparams.Upcoming),
 		}
 
-		cfg := config.Config{DefaultMaximumSearchResults: 1000, DefaultMaximumLimit: 100}
+		cfg := config.Config{DefaultMaximumSearchResults: 1000, DefaultMaximumLimit: 100, LimitOptions: []int{10, 25}, PaginationWindowSize: 5, SupportedLanguages: []string{"en", "cy"}}
 
 		Convey("CreateReleaseCalendar maps correctly to a model Calendar object", func() {
 			lang := "cy"
@@ -316,6 +320,9 @@ func TestReleaseCalendarMapper(t *testing.T) {
 			So(calendar.EmergencyBanner.URI, ShouldEqual, emergencyBannerURI)
 			So(calendar.EmergencyBanner.LinkText, ShouldEqual, emergencyBannerLinkText)
 			So(calendar.Metadata.Title, ShouldEqual, metaTitle)
+			So(calendar.Metadata.Description, ShouldEqual, "Datganiadau ystadegol sydd ar ddod ac sydd wedi'u cyhoeddi gan y Swyddfa Ystadegau Gwladol")
+			So(calendar.Social.Title, ShouldEqual, metaTitle)
+			So(calendar.Social.LocaleAlternates, ShouldResemble, []string{"en_GB"})
 			So(calendar.KeywordSearch.SearchTerm, ShouldEqual, params.Keywords)
 			So(calendar.Sort, ShouldResemble, model.Sort{Mode: params.Sort.String(), Options: mapSortOptions(params)})
 			So(calendar.BeforeDate, ShouldResemble, coreModel.DateFieldset{
@@ -451,6 +458,7 @@ func TestReleaseCalendarMapper(t *testing.T) {
 			So(calendar.Pagination.TotalPages, ShouldEqual, 3)
 			So(calendar.Pagination.CurrentPage, ShouldEqual, 1)
 			So(calendar.Pagination.Limit, ShouldEqual, 5)
+			So(calendar.Pagination.LimitOptions, ShouldResemble, cfg.LimitOptions)
 			So(calendar.TotalSearchPosition, ShouldEqual, 0)
 			So(calendar.Entries.Count, ShouldEqual, 11)
 			for i, r := range calendar.Entries.Items {
@@ -557,7 +565,7 @@ func TestReleaseCalendarMapper(t *testing.T) {
 			params.Offset = queryparams.CalculateOffset(params.Page, params.Limit)
 			validationErrs := []coreModel.ErrorItem{}
 
-			calendar := CreateReleaseCalendar(basePage, params, releaseResponse, cfg, "", "", zebedee.EmergencyBanner{}, validationErrs)
+			calendar := CreateReleaseCalendar(basePage, params, releaseResponse, cfg, "en", "", zebedee.EmergencyBanner{}, validationErrs)
 			So(calendar.Error.ErrorItems, ShouldNotBeEmpty)
 			So(calendar.Error.ErrorItems[0].Description.Text, ShouldEqual, fmt.Sprintf("invalid page parameter: value is above total pages (%d)", calendar.Pagination.TotalPages))
 		})
@@ -652,27 +660,27 @@ func TestGetPageURL(t *testing.T) {
 		}{
 			{
 				params: queryparams.ValidatedParams{
-					Limit:       10,
-					Page:        2,
-					AfterDate:   queryparams.MustParseDate("2021-11-30"),
-					Keywords:    "test",
-					Sort:        queryparams.TitleAZ,
-					ReleaseType: queryparams.Published,
-					Highlight:   true,
+					Limit:        10,
+					Page:         2,
+					AfterDate:    queryparams.MustParseDate("2021-11-30"),
+					Keywords:     "test",
+					Sort:         queryparams.TitleAZ,
+					ReleaseTypes: queryparams.NewReleaseTypes(queryparams.Published),
+					Highlight:    true,
 				},
 				path:     "/test-prefix/releasecalendar",
 				expected: "/test-prefix/releasecalendar?after-day=30&after-month=11&after-year=2021&highlight=true&keywords=test&limit=10&page=2&release-type=type-published&sort=alphabetical-az",
 			},
 			{
 				params: queryparams.ValidatedParams{
-					Limit:       25,
-					Page:        5,
-					BeforeDate:  queryparams.MustParseDate("2022-04-01"),
-					Sort:        queryparams.RelDateDesc,
-					ReleaseType: queryparams.Upcoming,
-					Provisional: true,
-					Postponed:   true,
-					Census:      true,
+					Limit:        25,
+					Page:         5,
+					BeforeDate:   queryparams.MustParseDate("2022-04-01"),
+					Sort:         queryparams.RelDateDesc,
+					ReleaseTypes: queryparams.NewReleaseTypes(queryparams.Upcoming),
+					Provisional:  true,
+					Postponed:    true,
+					Census:       true,
 				},
 				path:     "/releasecalendar",
 				expected: "/releasecalendar?before-day=1&before-month=4&before-year=2022&census=true&limit=25&page=5&release-type=type-upcoming&sort=date-newest&subtype-postponed=true&subtype-provisional=true",
@@ -686,3 +694,145 @@ func TestGetPageURL(t *testing.T) {
 		})
 	})
 }
+
+func TestMapReleases(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+	Convey("Given published and cancelled releases are requested, filtered to postponed releases", t, func() {
+		params := queryparams.ValidatedParams{
+			ReleaseTypes: queryparams.NewReleaseTypes(queryparams.Published, queryparams.Cancelled),
+			Postponed:    true,
+		}
+		response := sitesearch.ReleaseResponse{
+			Breakdown: sitesearch.Breakdown{Published: 7, Cancelled: 2, Provisional: 3, Confirmed: 4, Postponed: 1},
+		}
+
+		Convey("When the release type filters are mapped", func() {
+			releaseTypes := mapReleases(params, response, "en")
+
+			Convey("Then every requested release type is checked", func() {
+				So(releaseTypes["type-published"].IsChecked, ShouldBeTrue)
+				So(releaseTypes["type-cancelled"].IsChecked, ShouldBeTrue)
+				So(releaseTypes["type-upcoming"].IsChecked, ShouldBeFalse)
+			})
+
+			Convey("Then the upcoming subtypes are offered with their counts", func() {
+				subTypes := releaseTypes["type-upcoming"].SubTypes
+				So(subTypes, ShouldHaveLength, 3)
+				So(subTypes["subtype-provisional"].Label.Text, ShouldEqual, "Provisional (3)")
+				So(subTypes["subtype-provisional"].IsChecked, ShouldBeFalse)
+				So(subTypes["subtype-confirmed"].Count, ShouldEqual, 4)
+				So(subTypes["subtype-postponed"].Name, ShouldEqual, "subtype-postponed")
+				So(subTypes["subtype-postponed"].IsChecked, ShouldBeTrue)
+				So(releaseTypes["type-upcoming"].Count, ShouldEqual, 8)
+			})
+		})
+	})
+}
+
+func TestGetICSLink(t *testing.T) {
+	Convey("The ICS link covers every upcoming release when there are no filters", t, func() {
+		So(getICSLink(queryparams.ValidatedParams{}, "/calendar/releasecalendar"), ShouldEqual, "/calendar/releasecalendar")
+	})
+
+	Convey("The ICS link keeps the keywords and census filter", t, func() {
+		params := queryparams.ValidatedParams{Keywords: "gdp", Census: true}
+		So(getICSLink(params, "/calendar/releasecalendar"), ShouldEqual, "/calendar/releasecalendar?census=true&keywords=gdp")
+	})
+
+	Convey("The ICS link is served under the routing prefix", t, func() {
+		cfg := config.Config{RoutingPrefix: "/prefix"}
+		So(getICSLink(queryparams.ValidatedParams{Census: true}, cfg.ICSPath()), ShouldEqual, "/prefix/calendar/releasecalendar?census=true")
+	})
+}
+
+func TestMapKeywordChips(t *testing.T) {
+	Convey("Given a keyword search with a phrase and an exclusion on page 2", t, func() {
+		params := queryparams.ValidatedParams{
+			Limit:        10,
+			Page:         2,
+			Keywords:     `"labour market"  -scotland`,
+			Sort:         queryparams.Relevance,
+			ReleaseTypes: queryparams.NewReleaseTypes(queryparams.Published),
+		}
+
+		Convey("When the keyword chips are mapped", func() {
+			chips := mapKeywordChips(params, "/releasecalendar")
+
+			Convey("Then there is a chip for each term linking to the first page without it", func() {
+				So(chips, ShouldHaveLength, 2)
+				So(chips[0].Label, ShouldEqual, `"labour market"`)
+				So(chips[0].RemoveURL, ShouldEqual, "/releasecalendar?keywords=-scotland&limit=10&release-type=type-published&sort=relevance")
+				So(chips[1].Label, ShouldEqual, "-scotland")
+				So(chips[1].RemoveURL, ShouldEqual, "/releasecalendar?keywords=%22labour+market%22&limit=10&release-type=type-published&sort=relevance")
+			})
+		})
+	})
+
+	Convey("There are no chips without keywords", t, func() {
+		So(mapKeywordChips(queryparams.ValidatedParams{}, "/releasecalendar"), ShouldBeEmpty)
+	})
+}
+
+func TestCreateLoadMore(t *testing.T) {
+	cfg := config.Config{DefaultLimit: 10, DefaultMaximumSearchResults: 30}
+	params := queryparams.ValidatedParams{
+		Limit:        10,
+		Page:         1,
+		Sort:         queryparams.RelDateDesc,
+		ReleaseTypes: queryparams.NewReleaseTypes(queryparams.Published),
+	}
+
+	Convey("Given a page of the calendar list that is not the last that can be requested", t, func() {
+		loadMore := CreateLoadMore(params, cfg)
+
+		Convey("Then it links to the next page", func() {
+			So(loadMore, ShouldResemble, &model.LoadMore{URL: "/releasecalendar?limit=10&page=2&release-type=type-published&sort=date-newest"})
+		})
+	})
+
+	Convey("Given the last page of the calendar list that can be requested", t, func() {
+		params.Page = 3
+		Convey("Then there is no link to more releases", func() {
+			So(CreateLoadMore(params, cfg), ShouldBeNil)
+		})
+	})
+}
+
+func TestCreateReleaseCalendarPaginationConfig(t *testing.T) {
+	testcases := []struct {
+		limit, page, window int
+		options             []int
+		exPages             []int
+	}{
+		{limit: 10, page: 1, window: 5, options: []int{10, 25}, exPages: []int{1, 2, 3, 4, 5}},
+		{limit: 10, page: 6, window: 3, options: []int{10, 25}, exPages: []int{5, 6, 7}},
+		{limit: 25, page: 4, window: 5, options: []int{10, 25, 50}, exPages: []int{1, 2, 3, 4}},
+		{limit: 20, page: 4, window: 3, options: []int{20}, exPages: []int{3, 4, 5}},
+		// a window of one shows the next page
+		{limit: 50, page: 1, window: 1, options: []int{50}, exPages: []int{2}},
+	}
+
+	for _, tc := range testcases {
+		Convey(fmt.Sprintf("Given %d releases a page, a window of %d and limit options %v", tc.limit, tc.window, tc.options), t, func() {
+			cfg := config.Config{DefaultMaximumSearchResults: 1000, LimitOptions: tc.options, PaginationWindowSize: tc.window}
+			params := queryparams.ValidatedParams{
+				Limit:  tc.limit,
+				Page:   tc.page,
+				Offset: queryparams.CalculateOffset(tc.page, tc.limit),
+				Sort:   queryparams.RelDateDesc,
+			}
+			response := sitesearch.ReleaseResponse{Breakdown: sitesearch.Breakdown{Total: 100}}
+
+			Convey("Then the pagination uses them", func() {
+				calendar := CreateReleaseCalendar(coreModel.Page{}, params, response, cfg, "en", "", zebedee.EmergencyBanner{}, nil)
+				So(calendar.Pagination.LimitOptions, ShouldResemble, tc.options)
+				pages := make([]int, 0, len(calendar.Pagination.PagesToDisplay))
+				for _, p := range calendar.Pagination.PagesToDisplay {
+					pages = append(pages, p.PageNumber)
+				}
+				So(pages, ShouldResemble, tc.exPages)
+			})
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package mapper
+
+import (
+	"time"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	search "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/sitemap"
+)
+
+// CreateSitemapURL returns the sitemap entry of a release page in lang, with its alternates in each of the supported
+// languages. As the search API does not say when a release last changed, it is taken to be the latest of the release
+// date and the dates that the release was moved from that have passed.
+func CreateSitemapURL(release search.Release, lang string, cfg config.Config, now time.Time) sitemap.URL {
+	path := cfg.RoutingPrefix + release.URI
+	u := sitemap.URL{
+		Loc:     cfg.SiteURL(lang) + path,
+		LastMod: lastModified(release, now),
+	}
+
+	if len(cfg.SupportedLanguages) > 1 {
+		for _, l := range cfg.SupportedLanguages {
+			u.Alternates = append(u.Alternates, sitemap.Alternate{Hreflang: l, Href: cfg.SiteURL(l) + path})
+		}
+		u.Alternates = append(u.Alternates, sitemap.Alternate{Hreflang: "x-default", Href: cfg.SiteURL("en") + path})
+	}
+	return u
+}
+
+func lastModified(release search.Release, now time.Time) time.Time {
+	dates := []string{release.Description.ReleaseDate}
+	for _, change := range release.DateChanges {
+		dates = append(dates, change.Date)
+	}
+
+	var latest time.Time
+	for _, d := range dates {
+		t, err := time.Parse(time.RFC3339, d)
+		if err == nil && !t.After(now) && t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
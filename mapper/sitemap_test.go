@@ -0,0 +1,71 @@
+package mapper
+
+import (
+	"testing"
+	"time"
+
+	//nolint:staticcheck // using deprecated until migration to dp-search-api SDK
+	search "github.com/ONSdigital/dp-api-clients-go/v2/site-search"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/sitemap"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateSitemapURL(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	cfg := config.Config{SiteDomain: "ons.gov.uk", SupportedLanguages: []string{"en", "cy"}}
+
+	Convey("Given a published release", t, func() {
+		release := search.Release{
+			URI:         "/releases/gdp",
+			Description: search.ReleaseDescription{ReleaseDate: "2026-10-01T06:00:00.000Z", Published: true},
+		}
+
+		Convey("When it is listed in the Welsh sitemap", func() {
+			u := CreateSitemapURL(release, "cy", cfg, now)
+
+			Convey("Then its Welsh page is listed, with alternates in each language", func() {
+				So(u.Loc, ShouldEqual, "https://cy.ons.gov.uk/releases/gdp")
+				So(u.LastMod, ShouldEqual, time.Date(2026, 10, 1, 6, 0, 0, 0, time.UTC))
+				So(u.Alternates, ShouldResemble, []sitemap.Alternate{
+					{Hreflang: "en", Href: "https://www.ons.gov.uk/releases/gdp"},
+					{Hreflang: "cy", Href: "https://cy.ons.gov.uk/releases/gdp"},
+					{Hreflang: "x-default", Href: "https://www.ons.gov.uk/releases/gdp"},
+				})
+			})
+		})
+
+		Convey("When only English is supported", func() {
+			cfg.SupportedLanguages = []string{"en"}
+
+			Convey("Then it has no alternates", func() {
+				So(CreateSitemapURL(release, "en", cfg, now).Alternates, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the service has a routing prefix", func() {
+			cfg.RoutingPrefix = "/prefix"
+
+			Convey("Then its page is listed under the prefix", func() {
+				So(CreateSitemapURL(release, "en", cfg, now).Loc, ShouldEqual, "https://www.ons.gov.uk/prefix/releases/gdp")
+			})
+		})
+	})
+
+	Convey("Given an upcoming release that has been moved", t, func() {
+		release := search.Release{
+			URI:         "/releases/gdp",
+			Description: search.ReleaseDescription{ReleaseDate: "2026-11-01T07:00:00Z"},
+			DateChanges: []search.ReleaseDateChange{{Date: "2026-10-05T06:00:00Z"}, {Date: "2026-10-12T06:00:00Z"}},
+		}
+
+		Convey("Then it was last modified on the latest date that has passed", func() {
+			So(CreateSitemapURL(release, "en", cfg, now).LastMod, ShouldEqual, time.Date(2026, 10, 12, 6, 0, 0, 0, time.UTC))
+		})
+
+		Convey("Then it has no last modification if none of its dates have passed", func() {
+			release.DateChanges = []search.ReleaseDateChange{{Date: "2026-10-26T07:00:00Z"}}
+			So(CreateSitemapURL(release, "en", cfg, now).LastMod.IsZero(), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,102 @@
+package mapper
+
+import (
+	"strings"
+
+	"github.com/ONSdigital/dis-design-system-go/v2/helper"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+)
+
+const (
+	twitterCard = "summary"
+	twitterSite = "@ONS"
+)
+
+// ogLocales are the Open Graph locales of the languages that the site is written in
+var ogLocales = map[string]string{
+	"en": "en_GB",
+	"cy": "cy_GB",
+}
+
+// createReleaseSocialMetadata returns the metadata shown when a link to a release is shared
+func createReleaseSocialMetadata(release model.Release, cfg config.Config) model.SocialMetadata {
+	social := createSocialMetadata(release.Language, cfg.SupportedLanguages)
+	social.Type = "article"
+	social.Title = release.Description.Title
+	social.Description = release.Metadata.Description
+	social.URL = absoluteURL(cfg, release.Language, cfg.RoutingPrefix+release.URI)
+	return social
+}
+
+// createCalendarSocialMetadata returns the metadata shown when a link to the calendar is shared. Its URL is that of
+// the calendar until the canonical URL of the search is set with SetCanonicalURL.
+func createCalendarSocialMetadata(calendar model.Calendar, cfg config.Config) model.SocialMetadata {
+	social := createSocialMetadata(calendar.Language, cfg.SupportedLanguages)
+	social.Type = "website"
+	social.Title = calendar.Metadata.Title
+	social.Description = calendar.Metadata.Description
+	social.URL = absoluteURL(cfg, calendar.Language, cfg.CalendarPath())
+	return social
+}
+
+// createSocialMetadata returns the metadata common to every page in a language, which lists the other languages of
+// the site as alternate locales
+func createSocialMetadata(language string, supportedLanguages []string) model.SocialMetadata {
+	social := model.SocialMetadata{
+		SiteName:    publisherName,
+		Locale:      ogLocales[language],
+		TwitterCard: twitterCard,
+		TwitterSite: twitterSite,
+	}
+	for _, lang := range supportedLanguages {
+		if locale, ok := ogLocales[lang]; ok && lang != language {
+			social.LocaleAlternates = append(social.LocaleAlternates, locale)
+		}
+	}
+	return social
+}
+
+// createReleaseDescription returns the description of a release page, which the design system writes in the head of
+// the page for search engines and for the preview shown when a link to the release is shared. It gives the status and
+// date of the release ahead of its summary, as they are what people sharing a release most often want to know.
+func createReleaseDescription(release model.Release) string {
+	date := helper.DateTimeOnsDatePatternFormat(release.Description.ReleaseDate, release.Language)
+	if !release.Description.Finalised && release.Description.ProvisionalDate != "" {
+		date = release.Description.ProvisionalDate
+	}
+
+	parts := []string{localisedPublicationState(release.PublicationState, release.Language)}
+	if date != "" {
+		parts = append(parts, helper.Localise("ReleaseDate", release.Language, 1)+": "+date)
+	}
+	if summary := strings.TrimSuffix(strings.TrimSpace(release.Description.Summary), "."); summary != "" {
+		parts = append(parts, summary)
+	}
+	return strings.Join(parts, ". ") + "."
+}
+
+// SetCanonicalURL gives the calendar page its canonical URL, which is also the URL that links shared from the page
+// point to. The design system writes the canonical link in the head of the page from its URI.
+func SetCanonicalURL(cfg config.Config, calendar *model.Calendar, canonical string) {
+	calendar.URI = canonical
+	calendar.Social.URL = absoluteURL(cfg, calendar.Language, canonical)
+}
+
+// localisedPublicationState returns the name of a publication state in a language
+func localisedPublicationState(state model.PublicationState, language string) string {
+	key := "ReleaseStateUpcoming"
+	switch {
+	case state.Type == "published":
+		key = "ReleaseStatePublished"
+	case state.Type == "cancelled":
+		key = "ReleaseStateCancelled"
+	case state.SubType == "provisional":
+		key = "ReleaseStateProvisional"
+	case state.SubType == "confirmed":
+		key = "ReleaseStateConfirmed"
+	case state.SubType == "postponed":
+		key = "ReleaseStatePostponed"
+	}
+	return helper.Localise(key, language, 1)
+}
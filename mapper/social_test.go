@@ -0,0 +1,131 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dis-design-system-go/v2/helper"
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/mocks"
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateReleaseDescription(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+	Convey("Given a confirmed release", t, func() {
+		release := structuredRelease()
+		release.PublicationState = GetPublicationState(release.Description, release.DateChanges)
+		date := helper.DateTimeOnsDatePatternFormat(release.Description.ReleaseDate, "en")
+
+		Convey("Then its description gives its status, date and summary", func() {
+			So(createReleaseDescription(release), ShouldEqual,
+				"Confirmed. Release date: "+date+". Estimates of employment, unemployment and economic inactivity.")
+		})
+
+		Convey("When it is in Welsh", func() {
+			release.Language = "cy"
+
+			Convey("Then its description is in Welsh", func() {
+				So(createReleaseDescription(release), ShouldStartWith, "Cadarnhawyd. Dyddiad y datganiad: ")
+			})
+		})
+
+		Convey("When it has been postponed", func() {
+			release.DateChanges = []model.DateChange{{Date: "2026-10-14T06:00:00Z", ChangeNotice: "Delayed"}}
+			release.PublicationState = model.PublicationState{Type: "upcoming", SubType: "postponed"}
+
+			Convey("Then its description says so", func() {
+				So(createReleaseDescription(release), ShouldStartWith, "Postponed. ")
+			})
+		})
+
+		Convey("When it has only a provisional date and no summary", func() {
+			release.Description.Finalised = false
+			release.Description.ProvisionalDate = "October to November 2026"
+			release.Description.Summary = ""
+			release.PublicationState = GetPublicationState(release.Description, release.DateChanges)
+
+			Convey("Then its description gives the provisional date", func() {
+				So(createReleaseDescription(release), ShouldEqual, "Provisional. Release date: October to November 2026.")
+			})
+		})
+	})
+}
+
+func TestCreateReleaseSocialMetadata(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	cfg := config.Config{SiteDomain: "ons.gov.uk", SupportedLanguages: []string{"en", "cy"}}
+
+	Convey("Given a confirmed release", t, func() {
+		release := structuredRelease()
+		release.PublicationState = GetPublicationState(release.Description, release.DateChanges)
+		release.Metadata.Description = createReleaseDescription(release)
+
+		Convey("Then its preview gives its title, description and URL in English, with Welsh as the alternate locale", func() {
+			So(createReleaseSocialMetadata(release, cfg), ShouldResemble, model.SocialMetadata{
+				Type:             "article",
+				Title:            "Labour market overview, UK: October 2026",
+				Description:      release.Metadata.Description,
+				URL:              "https://www.ons.gov.uk/releases/labourmarketoverviewukoctober2026",
+				SiteName:         "Office for National Statistics",
+				Locale:           "en_GB",
+				LocaleAlternates: []string{"cy_GB"},
+				TwitterCard:      "summary",
+				TwitterSite:      "@ONS",
+			})
+		})
+
+		Convey("When it is in Welsh", func() {
+			release.Language = "cy"
+			release.Metadata.Description = createReleaseDescription(release)
+			social := createReleaseSocialMetadata(release, cfg)
+
+			Convey("Then its preview is in Welsh, on the Welsh site, with English as the alternate locale", func() {
+				So(social.Description, ShouldStartWith, "Cadarnhawyd. Dyddiad y datganiad: ")
+				So(social.URL, ShouldEqual, "https://cy.ons.gov.uk/releases/labourmarketoverviewukoctober2026")
+				So(social.Locale, ShouldEqual, "cy_GB")
+				So(social.LocaleAlternates, ShouldResemble, []string{"en_GB"})
+			})
+		})
+
+		Convey("When Welsh is not supported", func() {
+			cfg.SupportedLanguages = []string{"en"}
+
+			Convey("Then its preview has no alternate locale", func() {
+				So(createReleaseSocialMetadata(release, cfg).LocaleAlternates, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestCreateCalendarSocialMetadata(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	cfg := config.Config{SiteDomain: "ons.gov.uk", RoutingPrefix: "/prefix", SupportedLanguages: []string{"en", "cy"}}
+
+	Convey("Given the calendar in Welsh", t, func() {
+		calendar := model.Calendar{}
+		calendar.Language = "cy"
+		calendar.Metadata.Title = "Calendr datganiadau"
+		calendar.Metadata.Description = "Datganiadau ystadegol sydd ar ddod ac sydd wedi'u cyhoeddi gan y Swyddfa Ystadegau Gwladol"
+		calendar.Social = createCalendarSocialMetadata(calendar, cfg)
+
+		Convey("Then its preview describes the calendar in Welsh, with English as the alternate locale", func() {
+			So(calendar.Social.Type, ShouldEqual, "website")
+			So(calendar.Social.Title, ShouldEqual, "Calendr datganiadau")
+			So(calendar.Social.Description, ShouldEqual, calendar.Metadata.Description)
+			So(calendar.Social.URL, ShouldEqual, "https://cy.ons.gov.uk/prefix/releasecalendar")
+			So(calendar.Social.Locale, ShouldEqual, "cy_GB")
+			So(calendar.Social.LocaleAlternates, ShouldResemble, []string{"en_GB"})
+		})
+
+		Convey("When the canonical URL of its search is set", func() {
+			SetCanonicalURL(cfg, &calendar, "/prefix/releasecalendar?keywords=gdp")
+
+			Convey("Then it is the URI of the page, and links shared from the page point to it", func() {
+				So(calendar.URI, ShouldEqual, "/prefix/releasecalendar?keywords=gdp")
+				So(calendar.Social.URL, ShouldEqual, "https://cy.ons.gov.uk/prefix/releasecalendar?keywords=gdp")
+			})
+		})
+	})
+}
@@ -0,0 +1,108 @@
+package mapper
+
+import (
+	"context"
+	"encoding/json"
+	"html/template"
+	"strings"
+
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const (
+	publisherName = "Office for National Statistics"
+
+	eventScheduled = "https://schema.org/EventScheduled"
+	eventPostponed = "https://schema.org/EventPostponed"
+	eventCancelled = "https://schema.org/EventCancelled"
+)
+
+// publicationEvent is the schema.org PublicationEvent of a release, which search engines read from its JSON-LD
+type publicationEvent struct {
+	Context           string         `json:"@context"`
+	Type              string         `json:"@type"`
+	Name              string         `json:"name"`
+	Description       string         `json:"description,omitempty"`
+	URL               string         `json:"url"`
+	InLanguage        string         `json:"inLanguage,omitempty"`
+	StartDate         string         `json:"startDate,omitempty"`
+	PreviousStartDate string         `json:"previousStartDate,omitempty"`
+	EventStatus       string         `json:"eventStatus"`
+	PublishedBy       organization   `json:"publishedBy"`
+	WorkFeatured      []creativeWork `json:"workFeatured,omitempty"`
+}
+
+type organization struct {
+	Type         string        `json:"@type"`
+	Name         string        `json:"name"`
+	URL          string        `json:"url"`
+	ContactPoint *contactPoint `json:"contactPoint,omitempty"`
+}
+
+type contactPoint struct {
+	Type      string `json:"@type"`
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Telephone string `json:"telephone,omitempty"`
+}
+
+type creativeWork struct {
+	Type        string `json:"@type"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+}
+
+// createStructuredData returns the JSON-LD of a release. It is safe to write into a script element, as the JSON
+// encoder escapes the characters that could end it.
+func createStructuredData(release model.Release, cfg config.Config) template.JS {
+	event := publicationEvent{
+		Context:     "https://schema.org",
+		Type:        "PublicationEvent",
+		Name:        release.Description.Title,
+		Description: release.Description.Summary,
+		URL:         absoluteURL(cfg, release.Language, cfg.RoutingPrefix+release.URI),
+		InLanguage:  release.Language,
+		StartDate:   release.Description.ReleaseDate,
+		EventStatus: eventScheduled,
+		PublishedBy: organization{Type: "Organization", Name: publisherName, URL: cfg.SiteURL(release.Language)},
+	}
+
+	switch {
+	case release.PublicationState.Type == "cancelled":
+		event.EventStatus = eventCancelled
+	case release.PublicationState.SubType == "postponed":
+		event.EventStatus = eventPostponed
+		event.PreviousStartDate = release.DateChanges[len(release.DateChanges)-1].Date
+	}
+
+	if contact := release.Description.Contact; contact != (model.ContactDetails{}) {
+		event.PublishedBy.ContactPoint = &contactPoint{Type: "ContactPoint", Name: contact.Name, Email: contact.Email, Telephone: contact.Telephone}
+	}
+
+	for _, dataset := range release.RelatedDatasets {
+		event.WorkFeatured = append(event.WorkFeatured, creativeWork{
+			Type:        "Dataset",
+			Name:        dataset.Title,
+			Description: dataset.Summary,
+			URL:         absoluteURL(cfg, release.Language, dataset.URI),
+		})
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Error(context.Background(), "failed to marshal release structured data", err)
+		return ""
+	}
+	return template.JS(data) //nolint:gosec // encoding/json escapes <, > and & so the data cannot end the script
+}
+
+// absoluteURL returns the URL of a path on the site of lang, leaving URLs that are already absolute as they are
+func absoluteURL(cfg config.Config, lang, uri string) string {
+	if strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://") {
+		return uri
+	}
+	return cfg.SiteURL(lang) + uri
+}
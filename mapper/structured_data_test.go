@@ -0,0 +1,141 @@
+package mapper
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-frontend-release-calendar/config"
+	"github.com/ONSdigital/dp-frontend-release-calendar/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func structuredRelease() model.Release {
+	release := model.Release{
+		Description: model.ReleaseDescription{
+			Title:       "Labour market overview, UK: October 2026",
+			Summary:     "Estimates of employment, unemployment and economic inactivity.",
+			ReleaseDate: "2026-10-21T06:00:00Z",
+			Finalised:   true,
+			Contact:     model.ContactDetails{Name: "Labour Market team", Email: "labour.market@ons.gov.uk", Telephone: "+44 1633 455400"},
+		},
+		RelatedDatasets: []model.Link{
+			{Title: "Labour market statistics", Summary: "Time series", URI: "/employmentandlabourmarket/datasets/lms"},
+		},
+		DateChanges: []model.DateChange{},
+	}
+	release.URI = "/releases/labourmarketoverviewukoctober2026"
+	release.Language = "en"
+	return release
+}
+
+// decodeStructuredData decodes JSON-LD and checks that it has the schema.org types expected of a release
+func decodeStructuredData(data template.JS) map[string]interface{} {
+	var event map[string]interface{}
+	So(json.Unmarshal([]byte(data), &event), ShouldBeNil)
+	So(event["@context"], ShouldEqual, "https://schema.org")
+	So(event["@type"], ShouldEqual, "PublicationEvent")
+
+	publisher, ok := event["publishedBy"].(map[string]interface{})
+	So(ok, ShouldBeTrue)
+	So(publisher["@type"], ShouldEqual, "Organization")
+	So(publisher["name"], ShouldEqual, "Office for National Statistics")
+	if contact, ok := publisher["contactPoint"].(map[string]interface{}); ok {
+		So(contact["@type"], ShouldEqual, "ContactPoint")
+	}
+	if works, ok := event["workFeatured"].([]interface{}); ok {
+		for _, w := range works {
+			So(w.(map[string]interface{})["@type"], ShouldEqual, "Dataset")
+		}
+	}
+	return event
+}
+
+var structuredConfig = config.Config{SiteDomain: "ons.gov.uk"}
+
+func TestCreateStructuredData(t *testing.T) {
+	Convey("Given a confirmed release", t, func() {
+		release := structuredRelease()
+		release.PublicationState = GetPublicationState(release.Description, release.DateChanges)
+
+		Convey("Then it is described as a scheduled publication event", func() {
+			event := decodeStructuredData(createStructuredData(release, structuredConfig))
+			So(event["name"], ShouldEqual, release.Description.Title)
+			So(event["description"], ShouldEqual, release.Description.Summary)
+			So(event["url"], ShouldEqual, "https://www.ons.gov.uk/releases/labourmarketoverviewukoctober2026")
+			So(event["inLanguage"], ShouldEqual, "en")
+			So(event["startDate"], ShouldEqual, "2026-10-21T06:00:00Z")
+			So(event["eventStatus"], ShouldEqual, "https://schema.org/EventScheduled")
+			So(event, ShouldNotContainKey, "previousStartDate")
+
+			contact := event["publishedBy"].(map[string]interface{})["contactPoint"].(map[string]interface{})
+			So(contact["name"], ShouldEqual, "Labour Market team")
+			So(contact["email"], ShouldEqual, "labour.market@ons.gov.uk")
+			So(contact["telephone"], ShouldEqual, "+44 1633 455400")
+
+			datasets := event["workFeatured"].([]interface{})
+			So(datasets, ShouldHaveLength, 1)
+			So(datasets[0].(map[string]interface{})["name"], ShouldEqual, "Labour market statistics")
+			So(datasets[0].(map[string]interface{})["url"], ShouldEqual, "https://www.ons.gov.uk/employmentandlabourmarket/datasets/lms")
+		})
+	})
+
+	Convey("Given a postponed release", t, func() {
+		release := structuredRelease()
+		release.DateChanges = []model.DateChange{{Date: "2026-10-14T06:00:00Z", ChangeNotice: "Delayed to include revised data"}}
+		release.PublicationState = GetPublicationState(release.Description, release.DateChanges)
+
+		Convey("Then it is described as postponed from its previous date", func() {
+			event := decodeStructuredData(createStructuredData(release, structuredConfig))
+			So(event["eventStatus"], ShouldEqual, "https://schema.org/EventPostponed")
+			So(event["previousStartDate"], ShouldEqual, "2026-10-14T06:00:00Z")
+		})
+	})
+
+	Convey("Given a cancelled release without a contact or datasets", t, func() {
+		release := structuredRelease()
+		release.Description.Cancelled = true
+		release.Description.Contact = model.ContactDetails{}
+		release.RelatedDatasets = nil
+		release.PublicationState = GetPublicationState(release.Description, release.DateChanges)
+
+		Convey("Then it is described as cancelled", func() {
+			event := decodeStructuredData(createStructuredData(release, structuredConfig))
+			So(event["eventStatus"], ShouldEqual, "https://schema.org/EventCancelled")
+			So(event["publishedBy"], ShouldNotContainKey, "contactPoint")
+			So(event, ShouldNotContainKey, "workFeatured")
+		})
+	})
+
+	Convey("Given a Welsh release served under a routing prefix", t, func() {
+		release := structuredRelease()
+		release.Language = "cy"
+		release.PublicationState = GetPublicationState(release.Description, release.DateChanges)
+		cfg := config.Config{SiteDomain: "ons.gov.uk", RoutingPrefix: "/prefix"}
+
+		Convey("Then its URL is on the Welsh site, under the prefix", func() {
+			event := decodeStructuredData(createStructuredData(release, cfg))
+			So(event["url"], ShouldEqual, "https://cy.ons.gov.uk/prefix/releases/labourmarketoverviewukoctober2026")
+			So(event["publishedBy"].(map[string]interface{})["url"], ShouldEqual, "https://cy.ons.gov.uk")
+		})
+	})
+
+	Convey("Given a release whose title could end a script element", t, func() {
+		release := structuredRelease()
+		release.Description.Title = `GDP </script><script>alert("x")</script>`
+		data := createStructuredData(release, structuredConfig)
+
+		Convey("Then it is written into the script element of the template as JSON that cannot end it", func() {
+			tmpl := template.Must(template.New("release").Parse(`<script type="application/ld+json">{{ .StructuredData }}</script>`))
+			var b bytes.Buffer
+			So(tmpl.Execute(&b, model.Release{StructuredData: data}), ShouldBeNil)
+
+			script := strings.TrimSuffix(strings.TrimPrefix(b.String(), `<script type="application/ld+json">`), `</script>`)
+			So(script, ShouldNotContainSubstring, "<")
+			event := decodeStructuredData(template.JS(script))
+			So(event["name"], ShouldEqual, release.Description.Title)
+		})
+	})
+}
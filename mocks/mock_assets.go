@@ -11,6 +11,8 @@ var cyLocale = []string{
 	"one=\"Er enghraifft: 2006 neu 19 07 2010\"",
 	"[ReleaseCalendarPageTitle]",
 	"one=\"Calendr datganiadau\"",
+	"[ReleaseCalendarPageDescription]",
+	"one = \"Datganiadau ystadegol sydd ar ddod ac sydd wedi'u cyhoeddi gan y Swyddfa Ystadegau Gwladol\"",
 	"[BreadcrumbHome]",
 	"one=\"Hafan\"",
 	"[BreadcrumbReleaseCalendar]",
@@ -25,6 +27,12 @@ var cyLocale = []string{
 	"one = \"Ar ddod\"",
 	"[FilterReleaseTypeCancelled]",
 	"one = \"Canslwyd\"",
+	"[FilterReleaseTypeProvisional]",
+	"one = \"Dros dro\"",
+	"[FilterReleaseTypeConfirmed]",
+	"one = \"Cadarnhawyd\"",
+	"[FilterReleaseTypePostponed]",
+	"one = \"Wedi'i ohirio\"",
 	"[FilterReleaseTypeCensus]",
 	"one = \"Cyfrifiad\"",
 	"[ValidationPatternMismatch]",
@@ -33,6 +41,121 @@ var cyLocale = []string{
 	"one = \"Enter a year\"",
 	"[ValidationInvalidDate]",
 	"one = \"Enter a real date\"",
+	"[ValidationInvalidParameters]",
+	"one = \"Mae paramedr ymholiad yn annilys\"",
+	"other = \"Mae rhai paramedrau ymholiad yn annilys\"",
+	"[ValidationCursor]",
+	"one = \"Defnyddiwch y cyrchwr o ymateb blaenorol\"",
+	"[ValidationLimit]",
+	"one = \"Rhowch nifer o ganlyniadau rhwng 0 a {{.arg0}}\"",
+	"[ValidationPage]",
+	"one = \"Rhowch rif tudalen rhwng 1 a {{.arg0}}\"",
+	"[ValidationDateRangeOption]",
+	"one = \"Dewiswch ystod dyddiadau o'r rhestr\"",
+	"[ValidationSort]",
+	"one = \"Dewiswch drefn o'r rhestr\"",
+	"[ValidationSortRelevance]",
+	"one = \"Dewiswch un math o ryddhad i drefnu yn ôl perthnasedd\"",
+	"[ValidationKeywordsLength]",
+	"one = \"Rhowch eiriau allweddol sydd heb fod yn hwy na {{.arg0}} nod\"",
+	"[ValidationKeywordsControlCharacters]",
+	"one = \"Tynnwch unrhyw nodau rheoli o'r geiriau allweddol\"",
+	"[ValidationReleaseType]",
+	"one = \"Dewiswch fathau o ryddhad o'r rhestr\"",
+	"[ValidationBoolean]",
+	"one = \"Rhowch true neu false ar gyfer {{.arg0}}\"",
+	"[ValidationView]",
+	"one = \"Dewiswch olwg o'r rhestr\"",
+	"[ValidationViewDate]",
+	"one = \"Rhowch ddyddiad yn y fformat BBBB-MM-DD\"",
+	"[ValidationDateOrder]",
+	"one = \"Rhowch ddyddiad rhyddhau cyn sy'n hwyrach na'r dyddiad rhyddhau ar ôl\"",
+	"[MonthJanuary]",
+	"one = \"Ionawr\"",
+	"[MonthFebruary]",
+	"one = \"Chwefror\"",
+	"[MonthMarch]",
+	"one = \"Mawrth\"",
+	"[MonthApril]",
+	"one = \"Ebrill\"",
+	"[MonthMay]",
+	"one = \"Mai\"",
+	"[MonthJune]",
+	"one = \"Mehefin\"",
+	"[MonthJuly]",
+	"one = \"Gorffennaf\"",
+	"[MonthAugust]",
+	"one = \"Awst\"",
+	"[MonthSeptember]",
+	"one = \"Medi\"",
+	"[MonthOctober]",
+	"one = \"Hydref\"",
+	"[MonthNovember]",
+	"one = \"Tachwedd\"",
+	"[MonthDecember]",
+	"one = \"Rhagfyr\"",
+	"[WeekdayMonday]",
+	"one = \"Dydd Llun\"",
+	"[WeekdayTuesday]",
+	"one = \"Dydd Mawrth\"",
+	"[WeekdayWednesday]",
+	"one = \"Dydd Mercher\"",
+	"[WeekdayThursday]",
+	"one = \"Dydd Iau\"",
+	"[WeekdayFriday]",
+	"one = \"Dydd Gwener\"",
+	"[WeekdaySaturday]",
+	"one = \"Dydd Sadwrn\"",
+	"[WeekdaySunday]",
+	"one = \"Dydd Sul\"",
+	"[CalendarDayGroupingLabel]",
+	"one = \"Grwpio datganiadau\"",
+	"[CalendarGroupByDay]",
+	"one = \"Yn ôl diwrnod\"",
+	"[CalendarUngrouped]",
+	"one = \"Un rhestr\"",
+	"[CalendarViewList]",
+	"one = \"Rhestr\"",
+	"[CalendarViewMonth]",
+	"one = \"Mis\"",
+	"[CalendarViewWeek]",
+	"one = \"Wythnos\"",
+	"[CalendarGridWeekTitle]",
+	"one = \"Wythnos yn dechrau {{.arg0}}\"",
+	"[CalendarGridPrevious]",
+	"one = \"Blaenorol\"",
+	"[CalendarGridNext]",
+	"one = \"Nesaf\"",
+	"[CalendarDayContinued]",
+	"one = \"(parhad)\"",
+	"[DatePresetLabel]",
+	"one = \"Ystod dyddiadau\"",
+	"[DatePresetCustom]",
+	"one = \"Dewis dyddiadau\"",
+	"[DatePresetToday]",
+	"one = \"Heddiw\"",
+	"[DatePresetTomorrow]",
+	"one = \"Yfory\"",
+	"[DatePresetThisWeek]",
+	"one = \"Yr wythnos hon\"",
+	"[DatePresetNextWeek]",
+	"one = \"Yr wythnos nesaf\"",
+	"[DatePresetThisMonth]",
+	"one = \"Y mis hwn\"",
+	"[DatePresetNext30Days]",
+	"one = \"Y 30 diwrnod nesaf\"",
+	"[DatePresetLast7Days]",
+	"one = \"Y 7 diwrnod diwethaf\"",
+	"[ReleaseDate]",
+	"one = \"Dyddiad y datganiad\"",
+	"[ReleaseStateCancelled]",
+	"one = \"Canslwyd\"",
+	"[ReleaseStateConfirmed]",
+	"one = \"Cadarnhawyd\"",
+	"[ReleaseStatePostponed]",
+	"one = \"Wedi'i ohirio\"",
+	"[ReleaseStateProvisional]",
+	"one = \"Dros dro\"",
 }
 
 var enLocale = []string{
@@ -44,6 +167,8 @@ var enLocale = []string{
 	"one=\"For example: 2006 or 19 07 2010\"",
 	"[ReleaseCalendarPageTitle]",
 	"one=\"Release Calendar\"",
+	"[ReleaseCalendarPageDescription]",
+	"one = \"Upcoming and published statistical releases from the Office for National Statistics\"",
 	"[BreadcrumbHome]",
 	"one=\"Home\"",
 	"[BreadcrumbReleaseCalendar]",
@@ -58,6 +183,12 @@ var enLocale = []string{
 	"one = \"Upcoming\"",
 	"[FilterReleaseTypeCancelled]",
 	"one = \"Cancelled\"",
+	"[FilterReleaseTypeProvisional]",
+	"one = \"Provisional\"",
+	"[FilterReleaseTypeConfirmed]",
+	"one = \"Confirmed\"",
+	"[FilterReleaseTypePostponed]",
+	"one = \"Postponed\"",
 	"[FilterReleaseTypeCensus]",
 	"one = \"Census\"",
 	"[ValidationPatternMismatch]",
@@ -66,6 +197,121 @@ var enLocale = []string{
 	"one = \"Enter a year\"",
 	"[ValidationInvalidDate]",
 	"one = \"Enter a real date\"",
+	"[ValidationInvalidParameters]",
+	"one = \"A query parameter is invalid\"",
+	"other = \"Some query parameters are invalid\"",
+	"[ValidationCursor]",
+	"one = \"Use the cursor from a previous response\"",
+	"[ValidationLimit]",
+	"one = \"Enter a number of results from 0 to {{.arg0}}\"",
+	"[ValidationPage]",
+	"one = \"Enter a page number from 1 to {{.arg0}}\"",
+	"[ValidationDateRangeOption]",
+	"one = \"Choose a date range from the list\"",
+	"[ValidationSort]",
+	"one = \"Choose a sort order from the list\"",
+	"[ValidationSortRelevance]",
+	"one = \"Choose a single release type to sort by relevance\"",
+	"[ValidationKeywordsLength]",
+	"one = \"Enter keywords of no more than {{.arg0}} characters\"",
+	"[ValidationKeywordsControlCharacters]",
+	"one = \"Remove any control characters from the keywords\"",
+	"[ValidationReleaseType]",
+	"one = \"Choose release types from the list\"",
+	"[ValidationBoolean]",
+	"one = \"Enter true or false for {{.arg0}}\"",
+	"[ValidationView]",
+	"one = \"Choose a view from the list\"",
+	"[ValidationViewDate]",
+	"one = \"Enter a date in the format YYYY-MM-DD\"",
+	"[ValidationDateOrder]",
+	"one = \"Enter a released before date that is later than the released after date\"",
+	"[MonthJanuary]",
+	"one = \"January\"",
+	"[MonthFebruary]",
+	"one = \"February\"",
+	"[MonthMarch]",
+	"one = \"March\"",
+	"[MonthApril]",
+	"one = \"April\"",
+	"[MonthMay]",
+	"one = \"May\"",
+	"[MonthJune]",
+	"one = \"June\"",
+	"[MonthJuly]",
+	"one = \"July\"",
+	"[MonthAugust]",
+	"one = \"August\"",
+	"[MonthSeptember]",
+	"one = \"September\"",
+	"[MonthOctober]",
+	"one = \"October\"",
+	"[MonthNovember]",
+	"one = \"November\"",
+	"[MonthDecember]",
+	"one = \"December\"",
+	"[WeekdayMonday]",
+	"one = \"Monday\"",
+	"[WeekdayTuesday]",
+	"one = \"Tuesday\"",
+	"[WeekdayWednesday]",
+	"one = \"Wednesday\"",
+	"[WeekdayThursday]",
+	"one = \"Thursday\"",
+	"[WeekdayFriday]",
+	"one = \"Friday\"",
+	"[WeekdaySaturday]",
+	"one = \"Saturday\"",
+	"[WeekdaySunday]",
+	"one = \"Sunday\"",
+	"[CalendarDayGroupingLabel]",
+	"one = \"Group releases\"",
+	"[CalendarGroupByDay]",
+	"one = \"By day\"",
+	"[CalendarUngrouped]",
+	"one = \"Single list\"",
+	"[CalendarViewList]",
+	"one = \"List\"",
+	"[CalendarViewMonth]",
+	"one = \"Month\"",
+	"[CalendarViewWeek]",
+	"one = \"Week\"",
+	"[CalendarGridWeekTitle]",
+	"one = \"Week commencing {{.arg0}}\"",
+	"[CalendarGridPrevious]",
+	"one = \"Previous\"",
+	"[CalendarGridNext]",
+	"one = \"Next\"",
+	"[CalendarDayContinued]",
+	"one = \"(continued)\"",
+	"[DatePresetLabel]",
+	"one = \"Date range\"",
+	"[DatePresetCustom]",
+	"one = \"Choose dates\"",
+	"[DatePresetToday]",
+	"one = \"Today\"",
+	"[DatePresetTomorrow]",
+	"one = \"Tomorrow\"",
+	"[DatePresetThisWeek]",
+	"one = \"This week\"",
+	"[DatePresetNextWeek]",
+	"one = \"Next week\"",
+	"[DatePresetThisMonth]",
+	"one = \"This month\"",
+	"[DatePresetNext30Days]",
+	"one = \"Next 30 days\"",
+	"[DatePresetLast7Days]",
+	"one = \"Last 7 days\"",
+	"[ReleaseDate]",
+	"one = \"Release date\"",
+	"[ReleaseStateCancelled]",
+	"one = \"Cancelled\"",
+	"[ReleaseStateConfirmed]",
+	"one = \"Confirmed\"",
+	"[ReleaseStatePostponed]",
+	"one = \"Postponed\"",
+	"[ReleaseStateProvisional]",
+	"one = \"Provisional\"",
 }
 
 func MockAssetFunction(name string) ([]byte, error) {
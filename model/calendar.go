@@ -2,6 +2,7 @@ package model
 
 import (
 	coreModel "github.com/ONSdigital/dis-design-system-go/v2/model"
+	"github.com/ONSdigital/dp-frontend-release-calendar/featureflags"
 )
 
 type CalendarEntry struct {
@@ -40,12 +41,24 @@ type Sort struct {
 type Entries struct {
 	Count int             `json:"count"`
 	Items []CalendarEntry `json:"items"`
+	Days  []EntriesDay    `json:"days,omitempty"`
+}
+
+// EntriesDay is a heading for the entries on the page released on the same day in Europe/London. Start is the index
+// in Items of the first of them. A day is Continued when it began on the previous page.
+type EntriesDay struct {
+	Date      string `json:"date"`
+	Heading   string `json:"heading"`
+	Continued bool   `json:"continued"`
+	Start     int    `json:"start"`
+	Count     int    `json:"count"`
 }
 
 type Calendar struct {
 	coreModel.Page
 
 	RSSLink             string                  `json:"rss_link"`
+	ICSLink             string                  `json:"ics_link"`
 	ReleaseTypes        map[string]ReleaseType  `json:"release_types"`
 	Sort                Sort                    `json:"sort"`
 	Keywords            string                  `json:"keywords"`
@@ -53,8 +66,75 @@ type Calendar struct {
 	AfterDate           coreModel.DateFieldset  `json:"after_date"`
 	Entries             Entries                 `json:"entries"`
 	KeywordSearch       coreModel.CompactSearch `json:"keyword_search"`
+	KeywordChips        []KeywordChip           `json:"keyword_chips,omitempty"`
+	LoadMore            *LoadMore               `json:"load_more,omitempty"`
 	TotalSearchPosition int                     `json:"total_search_position,omitempty"`
 	FeedbackAPIURL      string                  `json:"feedback_api_url"`
+	Views               []ViewOption            `json:"views"`
+	DayGrouping         []ViewOption            `json:"day_grouping,omitempty"`
+	DatePresets         []DatePresetOption      `json:"date_presets"`
+	Grid                *CalendarGrid           `json:"grid,omitempty"`
+	Features            featureflags.Flags      `json:"features,omitempty"`
+	Social              SocialMetadata          `json:"social"`
+}
+
+// DatePresetOption is a date range relative to today offered in the date filter. The option with an empty Value
+// chooses the dates given in the date fieldsets instead.
+type DatePresetOption struct {
+	ID        string                 `json:"id"`
+	Value     string                 `json:"value"`
+	Label     coreModel.Localisation `json:"label"`
+	IsChecked bool                   `json:"is_checked"`
+}
+
+// KeywordChip is a term of the keyword query, such as a quoted phrase or an exclusion, with a link to search again
+// without it
+type KeywordChip struct {
+	Label     string `json:"label"`
+	RemoveURL string `json:"remove_url"`
+}
+
+// LoadMore links to the next page of the calendar list
+type LoadMore struct {
+	URL string `json:"url"`
+}
+
+// ViewOption is a link to show the calendar in another way, such as a list or a month or week grid
+type ViewOption struct {
+	Label     coreModel.Localisation `json:"label"`
+	URL       string                 `json:"url"`
+	IsCurrent bool                   `json:"is_current"`
+}
+
+// CalendarGrid is a month or week of releases, grouped by the day they are released on in Europe/London
+type CalendarGrid struct {
+	View     string         `json:"view"`
+	Title    string         `json:"title"`
+	Weekdays []string       `json:"weekdays"`
+	Weeks    []CalendarWeek `json:"weeks"`
+	Previous GridLink       `json:"previous"`
+	Next     GridLink       `json:"next"`
+}
+
+// CalendarWeek is a row of the grid, from Monday to Sunday
+type CalendarWeek struct {
+	Days []CalendarDay `json:"days"`
+}
+
+// CalendarDay holds the releases on a day of the grid. Days of a month grid that fall in the neighbouring months
+// are included to complete the first and last weeks, but are not in the period and have no entries.
+type CalendarDay struct {
+	Date     string          `json:"date"`
+	Day      int             `json:"day"`
+	InPeriod bool            `json:"in_period"`
+	IsToday  bool            `json:"is_today"`
+	Entries  []CalendarEntry `json:"entries"`
+}
+
+// GridLink links to the previous or next month or week of the grid
+type GridLink struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
 }
 
 func (calendar Calendar) FuncIsFilterSearchPresent() bool {
@@ -83,5 +163,15 @@ func (calendar Calendar) FuncIsFilterDatePresent() bool {
 			calendar.AfterDate.Input.InputValueYear != ""
 	}
 
-	return isBeforeDatePresent() || isAfterDatePresent()
+	return isBeforeDatePresent() || isAfterDatePresent() || calendar.FuncIsDatePresetChecked()
+}
+
+// FuncIsDatePresetChecked reports whether the dates are given by a date preset
+func (calendar Calendar) FuncIsDatePresetChecked() bool {
+	for _, p := range calendar.DatePresets {
+		if p.IsChecked && p.Value != "" {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,13 @@
+package model
+
+// ExportRow represents a single release in a spreadsheet export of the release calendar
+type ExportRow struct {
+	Title              string           `json:"title"`
+	URI                string           `json:"uri"`
+	ReleaseDate        string           `json:"release_date"`
+	ProvisionalDate    string           `json:"provisional_date"`
+	PublicationState   PublicationState `json:"publication_state"`
+	Census             bool             `json:"census"`
+	CancellationNotice string           `json:"cancellation_notice"`
+	PostponementReason string           `json:"postponement_reason"`
+}
@@ -1,7 +1,10 @@
 package model
 
 import (
+	"html/template"
+
 	coreModel "github.com/ONSdigital/dis-design-system-go/v2/model"
+	"github.com/ONSdigital/dp-frontend-release-calendar/featureflags"
 )
 
 type Link struct {
@@ -37,6 +40,9 @@ type Release struct {
 	AboutTheData              bool               `json:"about_the_data"`
 	PublicationState          PublicationState   `json:"publication_state"`
 	FeedbackAPIURL            string             `json:"feedback_api_url"`
+	Features                  featureflags.Flags `json:"features,omitempty"`
+	StructuredData            template.JS        `json:"structured_data,omitempty"`
+	Social                    SocialMetadata     `json:"social"`
 }
 
 type DateChange struct {
@@ -0,0 +1,15 @@
+package model
+
+// SocialMetadata is the Open Graph and Twitter card metadata of a page, which gives the preview shown when a link to
+// the page is shared
+type SocialMetadata struct {
+	Type             string   `json:"type"`
+	Title            string   `json:"title"`
+	Description      string   `json:"description"`
+	URL              string   `json:"url"`
+	SiteName         string   `json:"site_name"`
+	Locale           string   `json:"locale"`
+	LocaleAlternates []string `json:"locale_alternates,omitempty"`
+	TwitterCard      string   `json:"twitter_card"`
+	TwitterSite      string   `json:"twitter_site"`
+}
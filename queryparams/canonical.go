@@ -0,0 +1,49 @@
+package queryparams
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Defaults are the values that parameters take when they are not given. Sort is the name of the default sort order,
+// as configured.
+type Defaults struct {
+	Limit        int
+	Sort         string
+	ReleaseTypes ReleaseTypes
+}
+
+// CanonicalQuery returns the single form of the query for the search described by the parameters, so that the same
+// search is always found at the same URL. It is the frontend query without the parameters that have their default
+// values, and with the keywords written in a standard form.
+func (vp ValidatedParams) CanonicalQuery(defaults Defaults) url.Values {
+	query := vp.AsFrontendQuery()
+
+	if vp.Page <= 1 || vp.View.IsGrid() {
+		query.Del(Page)
+	}
+	if vp.Limit == defaults.Limit {
+		query.Del(Limit)
+	}
+	if strings.EqualFold(vp.Sort.String(), defaults.Sort) {
+		query.Del(SortName)
+	}
+	if slices.Equal(vp.ReleaseTypes, defaults.ReleaseTypes) {
+		query.Del(Type)
+	}
+	setValue(query, Keywords, ParseKeywords(vp.Keywords).String())
+	// turning grouping by day off has no effect on a list that cannot be grouped
+	if !vp.CanGroupByDay() {
+		query.Del(GroupByDay)
+	}
+
+	// highlighting is on by default, so only turning it off is kept
+	query.Del(Highlight)
+	if !vp.Highlight {
+		query.Set(Highlight, strconv.FormatBool(false))
+	}
+
+	return query
+}
@@ -0,0 +1,81 @@
+package queryparams
+
+import (
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCanonicalQuery(t *testing.T) {
+	defaults := Defaults{Limit: 10, Sort: "date-newest", ReleaseTypes: NewReleaseTypes(Published)}
+
+	Convey("Given parameters that all have their default values", t, func() {
+		vp := ValidatedParams{
+			Limit:        10,
+			Page:         1,
+			Sort:         RelDateDesc,
+			ReleaseTypes: NewReleaseTypes(Published),
+			Highlight:    true,
+		}
+
+		Convey("Then the canonical query is empty", func() {
+			So(vp.CanonicalQuery(defaults), ShouldBeEmpty)
+		})
+
+		Convey("When highlighting is turned off", func() {
+			vp.Highlight = false
+
+			Convey("Then only that is kept", func() {
+				So(vp.CanonicalQuery(defaults), ShouldResemble, url.Values{Highlight: []string{"false"}})
+			})
+		})
+
+		Convey("When grouping by day is turned off", func() {
+			vp.Ungrouped = true
+
+			Convey("Then only that is kept", func() {
+				So(vp.CanonicalQuery(defaults), ShouldResemble, url.Values{GroupByDay: []string{"false"}})
+			})
+
+			Convey("And the list is sorted by title", func() {
+				vp.Sort = TitleAZ
+
+				Convey("Then it is dropped, as the list cannot be grouped", func() {
+					So(vp.CanonicalQuery(defaults).Has(GroupByDay), ShouldBeFalse)
+				})
+			})
+		})
+	})
+
+	Convey("Given parameters that differ from the defaults", t, func() {
+		vp := ValidatedParams{
+			Limit:        25,
+			Page:         3,
+			Sort:         TitleAZ,
+			Keywords:     `  gdp   -"wales"  `,
+			ReleaseTypes: NewReleaseTypes(Upcoming),
+			Provisional:  true,
+			Highlight:    true,
+		}
+
+		Convey("Then they are kept, with the keywords written in a standard form", func() {
+			query := vp.CanonicalQuery(defaults)
+			So(query.Get(Limit), ShouldEqual, "25")
+			So(query.Get(Page), ShouldEqual, "3")
+			So(query.Get(SortName), ShouldEqual, TitleAZ.String())
+			So(query.Get(Keywords), ShouldEqual, `gdp -"wales"`)
+			So(query[Type], ShouldResemble, []string{Upcoming.Name()})
+			So(query.Get(Provisional.String()), ShouldEqual, "true")
+			So(query.Has(Highlight), ShouldBeFalse)
+		})
+
+		Convey("When the calendar is shown as a grid", func() {
+			vp.View = Month
+
+			Convey("Then the page is dropped", func() {
+				So(vp.CanonicalQuery(defaults).Has(Page), ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -11,6 +11,7 @@ type Date struct {
 	fieldsetErrID, fieldsetStr                                       string
 	assumedDay, assumedMonth                                         bool
 	hasDayValidationErr, hasMonthValidationErr, hasYearValidationErr bool
+	paramErrs                                                        []ParamError
 }
 
 const DateFormat = "2006-01-02"
@@ -60,6 +61,11 @@ func (d Date) String() string {
 	return d.date.UTC().Format(DateFormat)
 }
 
+// Time returns the date as midnight UTC, or the zero time if the date is not set
+func (d Date) Time() time.Time {
+	return d.date
+}
+
 func (d Date) YearString() string {
 	return d.ys
 }
@@ -93,3 +99,16 @@ func (d Date) HasMonthValidationErr() bool {
 func (d Date) HasYearValidationErr() bool {
 	return d.hasYearValidationErr
 }
+
+// ParamErrors returns the parameter responsible for each of the validation errors returned with the date, in the same order
+func (d Date) ParamErrors() []ParamError {
+	return d.paramErrs
+}
+
+// attributeParamErrs attributes the date's validation errors to param, for a date given as a single ISO 8601
+// parameter rather than as separate parameters for each part
+func (d *Date) attributeParamErrs(param string) {
+	for i := range d.paramErrs {
+		d.paramErrs[i].Param = param
+	}
+}
@@ -0,0 +1,181 @@
+package queryparams
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// MaxKeywordsLength is the greatest number of characters accepted in the "keywords" parameter
+const MaxKeywordsLength = 200
+
+var (
+	// ErrKeywordsTooLong is returned for a "keywords" parameter longer than MaxKeywordsLength
+	ErrKeywordsTooLong = fmt.Errorf("invalid %s parameter: enter no more than %d characters", Keywords, MaxKeywordsLength)
+	// ErrKeywordsControlCharacters is returned for a "keywords" parameter containing control characters
+	ErrKeywordsControlCharacters = fmt.Errorf("invalid %s parameter: remove any control characters", Keywords)
+)
+
+// simpleQueryPrefix asks the Search API to treat the query as an Elasticsearch simple query string, which supports
+// phrases and exclusions
+const simpleQueryPrefix = "!!s:"
+
+// KeywordTerm is a word or quoted phrase of a keyword query, which may be excluded with a leading "-"
+type KeywordTerm struct {
+	Text    string
+	Phrase  bool
+	Exclude bool
+}
+
+// String returns the term as it would be written in a keyword query
+func (t KeywordTerm) String() string {
+	var sb strings.Builder
+	if t.Exclude {
+		sb.WriteByte('-')
+	}
+	if t.Phrase {
+		sb.WriteString(`"` + t.Text + `"`)
+	} else {
+		sb.WriteString(t.Text)
+	}
+	return sb.String()
+}
+
+func (t KeywordTerm) isPlain() bool {
+	return !t.Phrase && !t.Exclude
+}
+
+// KeywordQuery is a parsed keyword query
+type KeywordQuery []KeywordTerm
+
+// ParseKeywords splits a keyword query into its terms. It never fails: a phrase missing its closing quote runs to the
+// end of the query, and a "-" with nothing after it is read as a word.
+func ParseKeywords(s string) KeywordQuery {
+	var terms KeywordQuery
+	rest := strings.TrimSpace(s)
+	for rest != "" {
+		var t KeywordTerm
+		if hasTermPrefix(rest, "-") {
+			t.Exclude = true
+			rest = rest[1:]
+		}
+
+		if rest[0] == '"' {
+			t.Phrase = true
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				t.Text, rest = rest[1:], ""
+			} else {
+				t.Text, rest = rest[1:end+1], rest[end+2:]
+			}
+			t.Text = strings.Join(strings.Fields(t.Text), " ")
+		} else {
+			end := strings.IndexFunc(rest, unicode.IsSpace)
+			if end < 0 {
+				end = len(rest)
+			}
+			t.Text, rest = rest[:end], rest[end:]
+		}
+
+		if t.Text != "" {
+			terms = append(terms, t)
+		}
+		rest = strings.TrimSpace(rest)
+	}
+
+	return terms
+}
+
+// hasTermPrefix reports whether s starts with prefix followed by the rest of a term
+func hasTermPrefix(s, prefix string) bool {
+	return len(s) > len(prefix) && strings.HasPrefix(s, prefix) && !unicode.IsSpace(rune(s[len(prefix)]))
+}
+
+// String returns the query as it would be written by a user
+func (q KeywordQuery) String() string {
+	terms := make([]string, len(q))
+	for i, t := range q {
+		terms[i] = t.String()
+	}
+	return strings.Join(terms, " ")
+}
+
+// Without returns the query without its i'th term
+func (q KeywordQuery) Without(i int) KeywordQuery {
+	terms := make(KeywordQuery, 0, len(q))
+	terms = append(terms, q[:i]...)
+	return append(terms, q[i+1:]...)
+}
+
+// BackendString returns the query as expected by the Search API. A query of plain words is sent as it is, so that it
+// is searched for in the usual way. Otherwise it is sent as a simple query string that matches any of the words and
+// phrases but none of the exclusions.
+func (q KeywordQuery) BackendString() string {
+	plain := true
+	for _, t := range q {
+		plain = plain && t.isPlain()
+	}
+	if plain {
+		return q.String()
+	}
+
+	var included, excluded []string
+	for _, t := range q {
+		term := escapeSimpleQuery(t.Text)
+		if t.Phrase {
+			term = `"` + term + `"`
+		}
+		if t.Exclude {
+			excluded = append(excluded, "-"+term)
+		} else {
+			included = append(included, term)
+		}
+	}
+
+	clauses := excluded
+	switch {
+	case len(included) == 1:
+		clauses = append([]string{included[0]}, excluded...)
+	case len(included) > 1:
+		clauses = append([]string{"(" + strings.Join(included, " | ") + ")"}, excluded...)
+	}
+
+	return simpleQueryPrefix + strings.Join(clauses, " + ")
+}
+
+// escapeSimpleQuery escapes the characters that are operators in a simple query string
+func escapeSimpleQuery(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`\+|-"*()~`, r) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// GetKeywords validates and returns the "keywords" parameter, which is rejected if it is too long or contains
+// control characters
+func GetKeywords(ctx context.Context, params url.Values, defaultValue string) (string, error) {
+	value := strings.TrimSpace(params.Get(Keywords))
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	if n := utf8.RuneCountInString(value); n > MaxKeywordsLength {
+		log.Warn(ctx, "keywords too long", log.Data{logKeyParam: Keywords, "length": n})
+		return defaultValue, ErrKeywordsTooLong
+	}
+	if strings.IndexFunc(value, unicode.IsControl) >= 0 {
+		log.Warn(ctx, "keywords contain control characters", log.Data{logKeyParam: Keywords, logKeyValue: value})
+		return defaultValue, ErrKeywordsControlCharacters
+	}
+
+	return value, nil
+}
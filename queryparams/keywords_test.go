@@ -0,0 +1,69 @@
+package queryparams
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseKeywords(t *testing.T) {
+	Convey("Given keyword queries using phrases and exclusions", t, func() {
+		testcases := []struct {
+			description string
+			query       string
+			exTerms     KeywordQuery
+		}{
+			{"plain words are separate terms", "  labour   market ", KeywordQuery{{Text: "labour"}, {Text: "market"}}},
+			{"a quoted phrase is a single term", `"labour market" wales`, KeywordQuery{{Text: "labour market", Phrase: true}, {Text: "wales"}}},
+			{"a leading minus excludes a term", "inflation -housing", KeywordQuery{{Text: "inflation"}, {Text: "housing", Exclude: true}}},
+			{"an excluded phrase", `-"quarterly update"`, KeywordQuery{{Text: "quarterly update", Phrase: true, Exclude: true}}},
+			{"an unclosed phrase runs to the end", `gdp "first estimate`, KeywordQuery{{Text: "gdp"}, {Text: "first estimate", Phrase: true}}},
+			{"empty phrases are ignored", `"" gdp`, KeywordQuery{{Text: "gdp"}}},
+			{"field prefixes are part of a word", "title:gdp cpih:2026 -", KeywordQuery{{Text: "title:gdp"}, {Text: "cpih:2026"}, {Text: "-"}}},
+		}
+
+		for _, tc := range testcases {
+			Convey(tc.description, func() {
+				So(ParseKeywords(tc.query), ShouldResemble, tc.exTerms)
+			})
+		}
+	})
+
+	Convey("An empty query has no terms", t, func() {
+		So(ParseKeywords("   "), ShouldBeEmpty)
+	})
+}
+
+func TestKeywordQuery(t *testing.T) {
+	Convey("Given a parsed keyword query", t, func() {
+		q := ParseKeywords(`"labour   market"  -scotland   wages`)
+
+		Convey("It is written back in a canonical form", func() {
+			So(q.String(), ShouldEqual, `"labour market" -scotland wages`)
+		})
+
+		Convey("A term can be removed", func() {
+			So(q.Without(1).String(), ShouldEqual, `"labour market" wages`)
+			So(q.String(), ShouldEqual, `"labour market" -scotland wages`)
+		})
+	})
+
+	Convey("Given keyword queries to send to the Search API", t, func() {
+		testcases := []struct {
+			description string
+			query       string
+			exBackend   string
+		}{
+			{"plain words are sent unchanged", "gdp  growth", "gdp growth"},
+			{"a phrase is sent as a simple query", `"labour market"`, `!!s:"labour market"`},
+			{"words and phrases are alternatives that must not match exclusions", `"labour market" wages -scotland -"northern ireland"`, `!!s:("labour market" | wages) + -scotland + -"northern ireland"`},
+			{"operators in terms are escaped", `-covid-19 "a|b"`, `!!s:"a\|b" + -covid\-19`},
+		}
+
+		for _, tc := range testcases {
+			Convey(tc.description, func() {
+				So(ParseKeywords(tc.query).BackendString(), ShouldEqual, tc.exBackend)
+			})
+		}
+	})
+}
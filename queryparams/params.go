@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -34,10 +35,29 @@ const (
 	Type        = "release-type"
 	Census      = "census"
 	Highlight   = "highlight"
+	GroupByDay  = "group-by-day"
+	Cursor      = "cursor"
+	ViewName    = "view"
+	ViewDate    = "date"
+	DateRange   = "date-range"
 	logKeyParam = "param"
 	logKeyValue = "value"
 )
 
+// Machine readable reasons for a query parameter failing validation
+const (
+	ErrCodeInvalidValue     = "invalid_value"
+	ErrCodeMissingValue     = "missing_value"
+	ErrCodeInvalidDate      = "invalid_date"
+	ErrCodeInvalidDateRange = "invalid_date_range"
+)
+
+// ParamError identifies the query parameter responsible for a validation error, and why it failed
+type ParamError struct {
+	Param string
+	Code  string
+}
+
 type intValidator func(valueAsString string) (int, error)
 
 // getIntValidator returns an IntValidator object using the min and max values provided
@@ -96,7 +116,7 @@ func validateAndGetIntParam(ctx context.Context, params url.Values, paramName st
 
 // GetSortOrder validates and returns the "sort" parameter
 func GetSortOrder(ctx context.Context, params url.Values, defaultValue string) (Sort, error) {
-	defaultSort, err := parseSort(defaultValue)
+	defaultSort, err := ParseSort(defaultValue)
 	if err != nil {
 		log.Warn(ctx, fmt.Sprintf("Invalid config value for default sort. Using %s as default", RelDateDesc.String()), log.Data{"value": defaultValue})
 		defaultSort = RelDateDesc
@@ -105,7 +125,7 @@ func GetSortOrder(ctx context.Context, params url.Values, defaultValue string) (
 	sort := defaultSort
 	asString := params.Get(SortName)
 	if asString != "" {
-		sort, err = parseSort(asString)
+		sort, err = ParseSort(asString)
 		if err != nil {
 			log.Warn(ctx, err.Error(), log.Data{logKeyParam: SortName, logKeyValue: asString})
 			return defaultSort, fmt.Errorf("invalid %s parameter: %s", SortName, err.Error())
@@ -120,35 +140,26 @@ func GetSortOrder(ctx context.Context, params url.Values, defaultValue string) (
 	return sort, nil
 }
 
-// GetKeywords validates and returns the "keywords" parameter
-func GetKeywords(_ context.Context, params url.Values, defaultValue string) (string, error) {
-	keywords := defaultValue
-
-	value := params.Get(Keywords)
-	if value != "" {
-		// Define any validation rules here. At present there are none, so we pass the given value directly
-		keywords = value
+// GetReleaseTypes validates and returns the set of release types given by the "release-type" parameter, which may
+// be repeated
+func GetReleaseTypes(ctx context.Context, params url.Values, defaultValue ReleaseTypes) (ReleaseTypes, error) {
+	var values []string
+	for _, v := range params[Type] {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return defaultValue, nil
 	}
 
-	return keywords, nil
-}
-
-// GetReleaseType validates and returns the "release-type" parameter
-func GetReleaseType(ctx context.Context, params url.Values, defaultValue ReleaseType) (ReleaseType, error) {
-	var (
-		relType = defaultValue
-		err     error
-	)
-	asString := params.Get(Type)
-	if asString != "" {
-		relType, err = parseReleaseType(asString)
-		if err != nil {
-			log.Warn(ctx, err.Error(), log.Data{logKeyParam: Type, logKeyValue: asString})
-			return defaultValue, fmt.Errorf("invalid %s parameter: %s", Type, err.Error())
-		}
+	relTypes, err := parseReleaseTypes(values)
+	if err != nil {
+		log.Warn(ctx, err.Error(), log.Data{logKeyParam: Type, logKeyValue: values})
+		return defaultValue, fmt.Errorf("invalid %s parameter: %s", Type, err.Error())
 	}
 
-	return relType, nil
+	return relTypes, nil
 }
 
 // GetBoolean finds a boolean parameter and returns a default value if not present
@@ -168,7 +179,9 @@ func GetBoolean(ctx context.Context, params url.Values, name string, defaultValu
 	return upcoming, nil
 }
 
-// GetStartDate returns the validated date from parameters
+// GetStartDate returns the validated date from parameters. The date is given either as separate "after-year",
+// "after-month" and "after-day" parameters or as an ISO 8601 "fromDate" parameter; when both are present the
+// separate parameters take precedence.
 func GetStartDate(params url.Values) (startDate Date, validationErrs []core.ErrorItem) {
 	var startTime time.Time
 
@@ -176,6 +189,13 @@ func GetStartDate(params url.Values) (startDate Date, validationErrs []core.Erro
 	startDate.fieldsetStr = After
 
 	yearAfterString, monthAfterString, dayAfterString := params.Get(YearAfter), params.Get(MonthAfter), params.Get(DayAfter)
+	isISODate := yearAfterString == "" && monthAfterString == "" && dayAfterString == "" && params.Get(DateFrom) != ""
+	if isISODate {
+		var ok bool
+		if yearAfterString, monthAfterString, dayAfterString, ok = isoDateParts(params.Get(DateFrom)); !ok {
+			return startDate, invalidISODate(&startDate, DateFrom)
+		}
+	}
 	startDate.ds = dayAfterString
 	startDate.ms = monthAfterString
 	startDate.ys = yearAfterString
@@ -189,6 +209,7 @@ func GetStartDate(params url.Values) (startDate Date, validationErrs []core.Erro
 			URL: fmt.Sprintf("#%s", DateFromErr),
 		})
 		startDate.hasYearValidationErr = true
+		startDate.paramErrs = append(startDate.paramErrs, ParamError{Param: YearAfter, Code: ErrCodeMissingValue})
 		return startDate, validationErrs
 	}
 
@@ -205,6 +226,9 @@ func GetStartDate(params url.Values) (startDate Date, validationErrs []core.Erro
 
 	startTime, validationErrs = getValidTimestamp(yearAfterString, monthAfterString, dayAfterString, &startDate)
 	if len(validationErrs) > 0 {
+		if isISODate {
+			startDate.attributeParamErrs(DateFrom)
+		}
 		return startDate, validationErrs
 	}
 
@@ -215,7 +239,8 @@ func GetStartDate(params url.Values) (startDate Date, validationErrs []core.Erro
 	return startDate, nil
 }
 
-// GetDates returns the validated date to parameters
+// GetEndDate returns the validated date to parameters. As with GetStartDate, the separate "before-" parameters take
+// precedence over an ISO 8601 "toDate" parameter.
 func GetEndDate(params url.Values) (endDate Date, validationErrs []core.ErrorItem) {
 	var endTime time.Time
 
@@ -223,6 +248,13 @@ func GetEndDate(params url.Values) (endDate Date, validationErrs []core.ErrorIte
 	endDate.fieldsetStr = Before
 
 	yearBeforeString, monthBeforeString, dayBeforeString := params.Get(YearBefore), params.Get(MonthBefore), params.Get(DayBefore)
+	isISODate := yearBeforeString == "" && monthBeforeString == "" && dayBeforeString == "" && params.Get(DateTo) != ""
+	if isISODate {
+		var ok bool
+		if yearBeforeString, monthBeforeString, dayBeforeString, ok = isoDateParts(params.Get(DateTo)); !ok {
+			return endDate, invalidISODate(&endDate, DateTo)
+		}
+	}
 	endDate.ds = dayBeforeString
 	endDate.ms = monthBeforeString
 	endDate.ys = yearBeforeString
@@ -236,6 +268,7 @@ func GetEndDate(params url.Values) (endDate Date, validationErrs []core.ErrorIte
 			URL: fmt.Sprintf("#%s", DateToErr),
 		})
 		endDate.hasYearValidationErr = true
+		endDate.paramErrs = append(endDate.paramErrs, ParamError{Param: YearBefore, Code: ErrCodeMissingValue})
 		return endDate, validationErrs
 	}
 
@@ -252,6 +285,9 @@ func GetEndDate(params url.Values) (endDate Date, validationErrs []core.ErrorIte
 
 	endTime, validationErrs = getValidTimestamp(yearBeforeString, monthBeforeString, dayBeforeString, &endDate)
 	if len(validationErrs) > 0 {
+		if isISODate {
+			endDate.attributeParamErrs(DateTo)
+		}
 		return endDate, validationErrs
 	}
 
@@ -262,6 +298,37 @@ func GetEndDate(params url.Values) (endDate Date, validationErrs []core.ErrorIte
 	return endDate, nil
 }
 
+var isoDatePattern = regexp.MustCompile(`^(\d{4})(?:-(\d{2})(?:-(\d{2}))?)?$`)
+
+// isoDateParts splits an ISO 8601 date (YYYY-MM-DD), or a partial date of a year and month (YYYY-MM) or a year
+// (YYYY), into its parts. Missing parts are returned empty, to be assumed as for the separate date parameters.
+func isoDateParts(value string) (year, month, day string, ok bool) {
+	matches := isoDatePattern.FindStringSubmatch(value)
+	if matches == nil {
+		return "", "", "", false
+	}
+
+	return matches[1], matches[2], matches[3], true
+}
+
+// invalidISODate records an ISO date parameter that could not be read against the fieldset of date
+func invalidISODate(date *Date, param string) []core.ErrorItem {
+	date.hasDayValidationErr = true
+	date.hasMonthValidationErr = true
+	date.hasYearValidationErr = true
+	date.paramErrs = append(date.paramErrs, ParamError{Param: param, Code: ErrCodeInvalidDate})
+
+	return []core.ErrorItem{
+		{
+			Description: core.Localisation{
+				Text: fmt.Sprintf("Enter the released %s date in the format YYYY-MM-DD, YYYY-MM or YYYY", date.fieldsetStr),
+			},
+			ID:  date.fieldsetErrID,
+			URL: fmt.Sprintf("#%s", date.fieldsetErrID),
+		},
+	}
+}
+
 // getValidTimestamp returns a valid timestamp or an error
 func getValidTimestamp(year, month, day string, date *Date) (time.Time, []core.ErrorItem) {
 	if year == "" || month == "" || day == "" {
@@ -280,6 +347,7 @@ func getValidTimestamp(year, month, day string, date *Date) (time.Time, []core.E
 			URL: fmt.Sprintf("#%s", date.fieldsetErrID),
 		})
 		date.hasDayValidationErr = true
+		date.paramErrs = append(date.paramErrs, ParamError{Param: date.fieldsetStr + "-day", Code: ErrCodeInvalidValue})
 	}
 
 	m, err := monthValidator(month)
@@ -292,6 +360,7 @@ func getValidTimestamp(year, month, day string, date *Date) (time.Time, []core.E
 			URL: fmt.Sprintf("#%s", date.fieldsetErrID),
 		})
 		date.hasMonthValidationErr = true
+		date.paramErrs = append(date.paramErrs, ParamError{Param: date.fieldsetStr + "-month", Code: ErrCodeInvalidValue})
 	}
 
 	y, err := yearValidator(year)
@@ -304,6 +373,7 @@ func getValidTimestamp(year, month, day string, date *Date) (time.Time, []core.E
 			URL: fmt.Sprintf("#%s", date.fieldsetErrID),
 		})
 		date.hasYearValidationErr = true
+		date.paramErrs = append(date.paramErrs, ParamError{Param: date.fieldsetStr + "-year", Code: ErrCodeInvalidValue})
 	}
 
 	// Throw errors back to user before further validation
@@ -327,6 +397,7 @@ func getValidTimestamp(year, month, day string, date *Date) (time.Time, []core.E
 		date.hasDayValidationErr = true
 		date.hasMonthValidationErr = true
 		date.hasYearValidationErr = true
+		date.paramErrs = append(date.paramErrs, ParamError{Param: date.fieldsetStr + "-day", Code: ErrCodeInvalidDate})
 	}
 
 	return timestamp, validationErrs
@@ -390,6 +461,12 @@ func CalculatePageNumber(offset, pageSize int) int {
 	return ((offset + 1) / pageSize) + 1
 }
 
+// MaximumPage returns the last page that can be requested when at most maxResults results are paged pageSize at a
+// time
+func MaximumPage(maxResults, pageSize int) int {
+	return CalculatePageNumber(maxResults-1, pageSize)
+}
+
 func setValue(query url.Values, key, value string) {
 	if value != "" {
 		query.Set(key, value)
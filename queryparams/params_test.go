@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -115,6 +116,23 @@ func TestGetLimit(t *testing.T) {
 	})
 }
 
+func TestMaximumPage(t *testing.T) {
+	Convey("The maximum page is the page holding the last result that can be requested", t, func() {
+		testcases := []struct{ maxResults, pageSize, exPage int }{
+			{maxResults: 1000, pageSize: 10, exPage: 100},
+			{maxResults: 1000, pageSize: 25, exPage: 40},
+			{maxResults: 1000, pageSize: 30, exPage: 34},
+			{maxResults: 1000, pageSize: 100, exPage: 10},
+			{maxResults: 1000, pageSize: 1000, exPage: 1},
+			{maxResults: 5, pageSize: 10, exPage: 1},
+			{maxResults: 1000, pageSize: 0, exPage: 1},
+		}
+		for _, tc := range testcases {
+			So(MaximumPage(tc.maxResults, tc.pageSize), ShouldEqual, tc.exPage)
+		}
+	})
+}
+
 func TestGetPage(t *testing.T) {
 	Convey("Given a list of params", t, func() {
 		ctx := context.Background()
@@ -326,6 +344,20 @@ func TestGetKeywords(t *testing.T) {
 			So(v, ShouldEqual, keywords)
 			So(e, ShouldBeNil)
 		})
+		Convey("if the string is too long, an error is returned", func() {
+			keywords = strings.Repeat("gdp ", MaxKeywordsLength/4) + "x"
+			v, e := GetKeywords(context.Background(), url.Values{Keywords: []string{keywords}}, "default")
+
+			So(v, ShouldEqual, "default")
+			So(e, ShouldResemble, errors.New("invalid keywords parameter: enter no more than 200 characters"))
+		})
+		Convey("if the string contains control characters, an error is returned", func() {
+			keywords = "gdp\x00 inflation"
+			v, e := GetKeywords(context.Background(), url.Values{Keywords: []string{keywords}}, "default")
+
+			So(v, ShouldEqual, "default")
+			So(e, ShouldResemble, errors.New("invalid keywords parameter: remove any control characters"))
+		})
 	})
 }
 
@@ -370,36 +402,39 @@ func TestGetBoolean(t *testing.T) {
 	})
 }
 
-func TestGetReleaseType(t *testing.T) {
+func TestGetReleaseTypes(t *testing.T) {
+	defaultTypes := NewReleaseTypes(Published)
+
 	Convey("given a set of erroneous release-type option strings", t, func() {
-		badReleaseTypes := []string{"coming-up", "finished", "done"}
-		defaultType := Published
-		Convey("When we call GetReleaseType(), it returns an error and the default sort option", func() {
+		badReleaseTypes := [][]string{{"coming-up"}, {"finished"}, {"type-published", "done"}, {"subtype-postponed"}}
+		Convey("When we call GetReleaseTypes(), it returns an error and the default release types", func() {
 			for _, rt := range badReleaseTypes {
-				v, e := GetReleaseType(context.Background(), url.Values{Type: []string{rt}}, defaultType)
+				v, e := GetReleaseTypes(context.Background(), url.Values{Type: rt}, defaultTypes)
 
-				So(v, ShouldEqual, defaultType)
+				So(v, ShouldResemble, defaultTypes)
 				So(e, ShouldNotBeNil)
 				So(e.Error(), ShouldEqual, "invalid release-type parameter: invalid release type string")
 			}
 		})
 	})
 
-	Convey("given a set of good release-type option", t, func() {
+	Convey("given a set of good release-type options", t, func() {
 		goodReleaseTypes := []struct {
-			given   string
-			exValue ReleaseType
+			given   []string
+			exValue ReleaseTypes
 		}{
-			{given: "type-upcoming", exValue: Upcoming},
-			{given: "type-published", exValue: Published},
-			{given: "type-cancelled", exValue: Cancelled},
+			{given: []string{"type-upcoming"}, exValue: ReleaseTypes{Upcoming}},
+			{given: []string{"type-published"}, exValue: ReleaseTypes{Published}},
+			{given: []string{"type-cancelled"}, exValue: ReleaseTypes{Cancelled}},
+			{given: []string{"type-cancelled", "type-published"}, exValue: ReleaseTypes{Published, Cancelled}},
+			{given: []string{"type-upcoming", "TYPE-UPCOMING", ""}, exValue: ReleaseTypes{Upcoming}},
+			{given: []string{""}, exValue: defaultTypes},
 		}
-		defaultType := Published
-		Convey("When we call GetReleaseType(), it returns the right value", func() {
+		Convey("When we call GetReleaseTypes(), it returns the right value", func() {
 			for _, grt := range goodReleaseTypes {
-				v, e := GetReleaseType(context.Background(), url.Values{Type: []string{grt.given}}, defaultType)
+				v, e := GetReleaseTypes(context.Background(), url.Values{Type: grt.given}, defaultTypes)
 
-				So(v, ShouldEqual, grt.exValue)
+				So(v, ShouldResemble, grt.exValue)
 				So(e, ShouldBeNil)
 			}
 		})
@@ -904,3 +939,120 @@ func TestCapitalizeFirstLetter(t *testing.T) {
 		})
 	})
 }
+
+func TestDateParamErrors(t *testing.T) {
+	Convey("Given invalid date parameters", t, func() {
+		testcases := []struct {
+			testDescription string
+			params          url.Values
+			exParamErrors   []ParamError
+		}{
+			{
+				testDescription: "for a day without a year",
+				params:          url.Values{"after-day": []string{"1"}},
+				exParamErrors:   []ParamError{{Param: "after-year", Code: ErrCodeMissingValue}},
+			},
+			{
+				testDescription: "for an invalid day and year",
+				params:          url.Values{"after-day": []string{"0"}, "after-month": []string{"1"}, "after-year": []string{"1800"}},
+				exParamErrors: []ParamError{
+					{Param: "after-day", Code: ErrCodeInvalidValue},
+					{Param: "after-year", Code: ErrCodeInvalidValue},
+				},
+			},
+			{
+				testDescription: "for a day that is not in the month",
+				params:          url.Values{"after-day": []string{"31"}, "after-month": []string{"4"}, "after-year": []string{"2021"}},
+				exParamErrors:   []ParamError{{Param: "after-day", Code: ErrCodeInvalidDate}},
+			},
+		}
+
+		Convey("check each validation error is paired with the parameter responsible for it", func() {
+			for _, tc := range testcases {
+				Convey(tc.testDescription, func() {
+					from, err := GetStartDate(tc.params)
+
+					So(err, ShouldHaveLength, len(tc.exParamErrors))
+					So(from.ParamErrors(), ShouldResemble, tc.exParamErrors)
+				})
+			}
+		})
+	})
+}
+
+func TestGetDatesFromISODates(t *testing.T) {
+	Convey("Given dates given as ISO 8601 parameters", t, func() {
+		testcases := []struct {
+			testDescription      string
+			params               url.Values
+			exFromDate, exToDate string
+			exParamErrors        []ParamError
+		}{
+			{
+				testDescription: "for full dates",
+				params:          url.Values{"fromDate": []string{"2026-03-01"}, "toDate": []string{"2026-03-31"}},
+				exFromDate:      "2026-03-01", exToDate: "2026-03-31",
+			},
+			{
+				testDescription: "for a year and month, the first day of the month is assumed",
+				params:          url.Values{"fromDate": []string{"2026-03"}, "toDate": []string{"2026-04"}},
+				exFromDate:      "2026-03-01", exToDate: "2026-04-01",
+			},
+			{
+				testDescription: "for a year, the first day of the year is assumed",
+				params:          url.Values{"fromDate": []string{"2026"}},
+				exFromDate:      "2026-01-01",
+			},
+			{
+				testDescription: "for both forms, the separate parameters take precedence",
+				params:          url.Values{"fromDate": []string{"2026-03-01"}, "after-year": []string{"2025"}, "after-month": []string{"6"}},
+				exFromDate:      "2025-06-01",
+			},
+			{
+				testDescription: "for an ISO date that can't be read",
+				params:          url.Values{"fromDate": []string{"1 March 2026"}},
+				exParamErrors:   []ParamError{{Param: "fromDate", Code: ErrCodeInvalidDate}},
+			},
+			{
+				testDescription: "for an ISO date that is not in the calendar",
+				params:          url.Values{"fromDate": []string{"2026-02-30"}},
+				exParamErrors:   []ParamError{{Param: "fromDate", Code: ErrCodeInvalidDate}},
+			},
+			{
+				testDescription: "for an ISO date outside the years accepted",
+				params:          url.Values{"fromDate": []string{"1800-01-01"}},
+				exParamErrors:   []ParamError{{Param: "fromDate", Code: ErrCodeInvalidValue}},
+			},
+		}
+
+		Convey("check that the dates are read, with any errors attributed to the ISO parameters", func() {
+			for _, tc := range testcases {
+				Convey(tc.testDescription, func() {
+					from, fromErrs := GetStartDate(tc.params)
+					to, toErrs := GetEndDate(tc.params)
+
+					So(from.String(), ShouldEqual, tc.exFromDate)
+					So(to.String(), ShouldEqual, tc.exToDate)
+					So(toErrs, ShouldBeEmpty)
+					So(from.ParamErrors(), ShouldResemble, tc.exParamErrors)
+					So(fromErrs, ShouldHaveLength, len(tc.exParamErrors))
+					for _, err := range fromErrs {
+						So(err.ID, ShouldEqual, DateFromErr)
+					}
+				})
+			}
+		})
+	})
+
+	Convey("Given an invalid ISO end date", t, func() {
+		to, errs := GetEndDate(url.Values{"toDate": []string{"2026/03/01"}})
+
+		Convey("Then the error is routed to the released before fieldset", func() {
+			So(errs, ShouldHaveLength, 1)
+			So(errs[0].ID, ShouldEqual, DateToErr)
+			So(errs[0].Description.Text, ShouldEqual, "Enter the released before date in the format YYYY-MM-DD, YYYY-MM or YYYY")
+			So(to.ParamErrors(), ShouldResemble, []ParamError{{Param: "toDate", Code: ErrCodeInvalidDate}})
+			So(to.HasYearValidationErr(), ShouldBeTrue)
+		})
+	})
+}
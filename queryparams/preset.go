@@ -0,0 +1,99 @@
+package queryparams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// DatePreset is a date range relative to today, such as "next-week", that is resolved to release dates when a
+// request is made rather than when a link to the calendar is created
+type DatePreset int
+
+const (
+	NoDatePreset DatePreset = iota
+	Today
+	Tomorrow
+	ThisWeek
+	NextWeek
+	ThisMonth
+	Next30Days
+	Last7Days
+)
+
+// DatePresets lists the presets in the order they are offered to users
+var DatePresets = []DatePreset{Today, Tomorrow, ThisWeek, NextWeek, ThisMonth, Next30Days, Last7Days}
+
+var datePresetValues = map[DatePreset]string{
+	Today:      "today",
+	Tomorrow:   "tomorrow",
+	ThisWeek:   "this-week",
+	NextWeek:   "next-week",
+	ThisMonth:  "this-month",
+	Next30Days: "next-30-days",
+	Last7Days:  "last-7-days",
+}
+
+func parseDatePreset(s string) (DatePreset, error) {
+	for p, name := range datePresetValues {
+		if s == name {
+			return p, nil
+		}
+	}
+
+	return NoDatePreset, errors.New("invalid date range option string")
+}
+
+func (p DatePreset) String() string {
+	return datePresetValues[p]
+}
+
+// Dates resolves the preset to the first and last days of its range, counting days in Europe/London. The ranges
+// include today, so "next-30-days" runs from today to 29 days after it and "last-7-days" from 6 days before today.
+func (p DatePreset) Dates(now time.Time) (after, before Date) {
+	y, m, d := now.In(London).Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	var start, end time.Time
+	switch p {
+	case Today:
+		start, end = today, today
+	case Tomorrow:
+		start = today.AddDate(0, 0, 1)
+		end = start
+	case ThisWeek:
+		start, end = Week.Window(today)
+	case NextWeek:
+		start, end = Week.Window(today.AddDate(0, 0, 7))
+	case ThisMonth:
+		start, end = Month.Window(today)
+	case Next30Days:
+		start, end = today, today.AddDate(0, 0, 29)
+	case Last7Days:
+		start, end = today.AddDate(0, 0, -6), today
+	default:
+		return Date{}, Date{}
+	}
+
+	return DateFromTime(start), DateFromTime(end)
+}
+
+// GetDatePreset validates and returns the "date-range" parameter
+func GetDatePreset(ctx context.Context, params url.Values) (DatePreset, error) {
+	asString := params.Get(DateRange)
+	if asString == "" {
+		return NoDatePreset, nil
+	}
+
+	preset, err := parseDatePreset(asString)
+	if err != nil {
+		log.Warn(ctx, err.Error(), log.Data{logKeyParam: DateRange, logKeyValue: asString})
+		return NoDatePreset, fmt.Errorf("invalid %s parameter: %s", DateRange, err.Error())
+	}
+
+	return preset, nil
+}
@@ -0,0 +1,89 @@
+package queryparams
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDatePresetDates(t *testing.T) {
+	Convey("Given it is Wednesday 21 October 2026", t, func() {
+		now := time.Date(2026, 10, 21, 12, 0, 0, 0, time.UTC)
+
+		testcases := []struct {
+			preset            DatePreset
+			exAfter, exBefore string
+		}{
+			{Today, "2026-10-21", "2026-10-21"},
+			{Tomorrow, "2026-10-22", "2026-10-22"},
+			{ThisWeek, "2026-10-19", "2026-10-25"},
+			{NextWeek, "2026-10-26", "2026-11-01"},
+			{ThisMonth, "2026-10-01", "2026-10-31"},
+			{Next30Days, "2026-10-21", "2026-11-19"},
+			{Last7Days, "2026-10-15", "2026-10-21"},
+		}
+
+		for _, tc := range testcases {
+			Convey("the "+tc.preset.String()+" preset is resolved", func() {
+				after, before := tc.preset.Dates(now)
+				So(after.String(), ShouldEqual, tc.exAfter)
+				So(before.String(), ShouldEqual, tc.exBefore)
+			})
+		}
+	})
+
+	Convey("Given it is late on Sunday evening in UTC but Monday in Europe/London", t, func() {
+		now := time.Date(2026, 10, 18, 23, 30, 0, 0, time.UTC)
+
+		Convey("Then today is the Monday", func() {
+			after, before := Today.Dates(now)
+			So(after.String(), ShouldEqual, "2026-10-19")
+			So(before.String(), ShouldEqual, "2026-10-19")
+
+			after, before = ThisWeek.Dates(now)
+			So(after.String(), ShouldEqual, "2026-10-19")
+			So(before.String(), ShouldEqual, "2026-10-25")
+		})
+	})
+
+	Convey("Given it is the last day of the year", t, func() {
+		now := time.Date(2026, 12, 31, 9, 0, 0, 0, time.UTC)
+
+		Convey("Then tomorrow is in the next year", func() {
+			after, _ := Tomorrow.Dates(now)
+			So(after.String(), ShouldEqual, "2027-01-01")
+		})
+	})
+
+	Convey("No preset resolves to no dates", t, func() {
+		after, before := NoDatePreset.Dates(time.Now())
+		So(after.String(), ShouldBeEmpty)
+		So(before.String(), ShouldBeEmpty)
+	})
+}
+
+func TestGetDatePreset(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("There is no preset by default", t, func() {
+		preset, err := GetDatePreset(ctx, url.Values{})
+		So(err, ShouldBeNil)
+		So(preset, ShouldEqual, NoDatePreset)
+	})
+
+	Convey("Every preset can be parsed from its name", t, func() {
+		for _, p := range DatePresets {
+			preset, err := GetDatePreset(ctx, url.Values{DateRange: []string{p.String()}})
+			So(err, ShouldBeNil)
+			So(preset, ShouldEqual, p)
+		}
+	})
+
+	Convey("Other presets are rejected", t, func() {
+		_, err := GetDatePreset(ctx, url.Values{DateRange: []string{"next-year"}})
+		So(err, ShouldNotBeNil)
+	})
+}
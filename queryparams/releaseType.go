@@ -2,6 +2,7 @@ package queryparams
 
 import (
 	"errors"
+	"sort"
 	"strings"
 )
 
@@ -47,3 +48,63 @@ func (rt ReleaseType) Label() string {
 func (rt ReleaseType) String() string {
 	return rt.Name()
 }
+
+// ReleaseTypes is a set of the release types upcoming, published and cancelled, held in that order
+type ReleaseTypes []ReleaseType
+
+// NewReleaseTypes returns the set of the given release types
+func NewReleaseTypes(types ...ReleaseType) ReleaseTypes {
+	set := make(ReleaseTypes, 0, len(types))
+	for _, rt := range types {
+		if !set.Has(rt) {
+			set = append(set, rt)
+		}
+	}
+	sort.Slice(set, func(i, j int) bool { return set[i] < set[j] })
+
+	return set
+}
+
+// Has reports whether the set includes rt
+func (rts ReleaseTypes) Has(rt ReleaseType) bool {
+	for _, t := range rts {
+		if t == rt {
+			return true
+		}
+	}
+	return false
+}
+
+// Only reports whether rt is the only release type in the set
+func (rts ReleaseTypes) Only(rt ReleaseType) bool {
+	return len(rts) == 1 && rts[0] == rt
+}
+
+// Names returns the names of the release types in the set, as given in the "release-type" parameter
+func (rts ReleaseTypes) Names() []string {
+	names := make([]string, 0, len(rts))
+	for _, rt := range rts {
+		names = append(names, rt.Name())
+	}
+	return names
+}
+
+func (rts ReleaseTypes) String() string {
+	return strings.Join(rts.Names(), ",")
+}
+
+func parseReleaseTypes(values []string) (ReleaseTypes, error) {
+	types := make([]ReleaseType, 0, len(values))
+	for _, v := range values {
+		rt, err := parseReleaseType(v)
+		if err != nil {
+			return nil, err
+		}
+		if rt != Upcoming && rt != Published && rt != Cancelled {
+			return nil, errors.New("invalid release type string")
+		}
+		types = append(types, rt)
+	}
+
+	return NewReleaseTypes(types...), nil
+}
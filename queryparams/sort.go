@@ -24,7 +24,8 @@ var sortValues = map[Sort]struct{ feValue, beValue string }{
 	Relevance:   {feValue: "relevance", beValue: "relevance"},
 }
 
-func parseSort(sort string) (Sort, error) {
+// ParseSort returns the sort order with the given frontend name
+func ParseSort(sort string) (Sort, error) {
 	for s, sv := range sortValues {
 		if strings.EqualFold(sort, sv.feValue) {
 			return s, nil
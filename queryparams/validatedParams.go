@@ -6,19 +6,23 @@ import (
 )
 
 type ValidatedParams struct {
-	Limit       int
-	Page        int
-	Offset      int
-	AfterDate   Date
-	BeforeDate  Date
-	Keywords    string
-	Sort        Sort
-	ReleaseType ReleaseType
-	Provisional bool
-	Confirmed   bool
-	Postponed   bool
-	Census      bool
-	Highlight   bool
+	Limit        int
+	Page         int
+	Offset       int
+	AfterDate    Date
+	BeforeDate   Date
+	DatePreset   DatePreset
+	Keywords     string
+	Sort         Sort
+	ReleaseTypes ReleaseTypes
+	Provisional  bool
+	Confirmed    bool
+	Postponed    bool
+	Census       bool
+	Highlight    bool
+	Ungrouped    bool // the group-by-day parameter is false, so a list is not grouped under day headings
+	View         View
+	ViewDate     Date
 }
 
 // AsBackendQuery converts to a url.Values object with parameters as expected by the api
@@ -38,40 +42,58 @@ func (vp ValidatedParams) asQuery(isBackend bool) url.Values {
 
 	if isBackend {
 		setValue(query, Offset, strconv.Itoa(vp.Offset))
-		setValue(query, Query, vp.Keywords)
+		setValue(query, Query, ParseKeywords(vp.Keywords).BackendString())
 		setValue(query, SortName, vp.getSortBackendString())
 		setValue(query, DateFrom, vp.AfterDate.String())
 		setValue(query, DateTo, vp.BeforeDate.String())
 	} else {
 		setValue(query, Keywords, vp.Keywords)
 		setValue(query, SortName, vp.Sort.String())
-		setValue(query, YearBefore, vp.BeforeDate.YearString())
-		setValue(query, MonthBefore, vp.BeforeDate.MonthString())
-		setValue(query, DayBefore, vp.BeforeDate.DayString())
-		setValue(query, YearAfter, vp.AfterDate.YearString())
-		setValue(query, MonthAfter, vp.AfterDate.MonthString())
-		setValue(query, DayAfter, vp.AfterDate.DayString())
+		if vp.DatePreset != NoDatePreset {
+			// keep the preset so that links resolve it again rather than fixing the dates it resolved to
+			setValue(query, DateRange, vp.DatePreset.String())
+		} else {
+			setValue(query, YearBefore, vp.BeforeDate.YearString())
+			setValue(query, MonthBefore, vp.BeforeDate.MonthString())
+			setValue(query, DayBefore, vp.BeforeDate.DayString())
+			setValue(query, YearAfter, vp.AfterDate.YearString())
+			setValue(query, MonthAfter, vp.AfterDate.MonthString())
+			setValue(query, DayAfter, vp.AfterDate.DayString())
+		}
+		if vp.View != List {
+			setValue(query, ViewName, vp.View.String())
+			setValue(query, ViewDate, vp.ViewDate.String())
+		}
 	}
 
-	setValue(query, Type, vp.ReleaseType.String())
-	if vp.ReleaseType == Upcoming {
-		setBoolValue(query, Provisional.String(), vp.Provisional)
-		setBoolValue(query, Confirmed.String(), vp.Confirmed)
-		setBoolValue(query, Postponed.String(), vp.Postponed)
+	for _, rt := range vp.ReleaseTypes {
+		query.Add(Type, rt.Name())
 	}
+	setBoolValue(query, Provisional.String(), vp.Provisional)
+	setBoolValue(query, Confirmed.String(), vp.Confirmed)
+	setBoolValue(query, Postponed.String(), vp.Postponed)
 	setBoolValue(query, Census, vp.Census)
 	setBoolValue(query, Highlight, vp.Highlight)
+	// grouping by day is on by default and only changes the page, so only turning it off is kept in its links
+	if !isBackend && vp.Ungrouped {
+		query.Set(GroupByDay, strconv.FormatBool(false))
+	}
 
 	return query
 }
 
+// CanGroupByDay reports whether the parameters give a list in release date order, which can be grouped by day
+func (vp ValidatedParams) CanGroupByDay() bool {
+	return !vp.View.IsGrid() && (vp.Sort == RelDateAsc || vp.Sort == RelDateDesc)
+}
+
 func (vp ValidatedParams) getSortBackendString() string {
 	// Newest is now defined as 'the closest date to today' and so its
 	// meaning in terms of algorithmic definition (ascending/descending)
-	// is reversed depending on the release-type that is being viewed
-	if vp.ReleaseType == Upcoming && vp.Sort == RelDateDesc {
+	// is reversed when only upcoming releases are being viewed
+	if vp.ReleaseTypes.Only(Upcoming) && vp.Sort == RelDateDesc {
 		return RelDateAsc.BackendString()
-	} else if vp.ReleaseType == Upcoming && vp.Sort == RelDateAsc {
+	} else if vp.ReleaseTypes.Only(Upcoming) && vp.Sort == RelDateAsc {
 		return RelDateDesc.BackendString()
 	}
 	return vp.Sort.BackendString()
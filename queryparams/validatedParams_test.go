@@ -22,7 +22,7 @@ func TestAsFrontendQuery(t *testing.T) {
 		}
 
 		Convey("And the release type is upcoming", func() {
-			vp.ReleaseType = Upcoming
+			vp.ReleaseTypes = NewReleaseTypes(Upcoming)
 
 			Convey("When we call AsFrontendQuery", func() {
 				uv := vp.AsFrontendQuery()
@@ -37,7 +37,7 @@ func TestAsFrontendQuery(t *testing.T) {
 					So(uv.Get(DayBefore), ShouldEqual, "")
 					So(uv.Get(Keywords), ShouldEqual, "some keywords")
 					So(uv.Get(SortName), ShouldEqual, vp.Sort.String())
-					So(uv.Get(Type), ShouldEqual, vp.ReleaseType.String())
+					So(uv.Get(Type), ShouldEqual, vp.ReleaseTypes[0].Name())
 					So(uv.Get(Provisional.String()), ShouldEqual, "true")
 					So(uv.Get(Confirmed.String()), ShouldEqual, "true")
 					So(uv.Get(Postponed.String()), ShouldEqual, "true")
@@ -53,7 +53,7 @@ func TestAsFrontendQuery(t *testing.T) {
 		})
 
 		Convey("And the release type is not upcoming", func() {
-			vp.ReleaseType = Cancelled
+			vp.ReleaseTypes = NewReleaseTypes(Cancelled)
 
 			Convey("When we call AsFrontendQuery", func() {
 				uv := vp.AsFrontendQuery()
@@ -68,16 +68,16 @@ func TestAsFrontendQuery(t *testing.T) {
 					So(uv.Get(DayBefore), ShouldEqual, "")
 					So(uv.Get(Keywords), ShouldEqual, "some keywords")
 					So(uv.Get(SortName), ShouldEqual, vp.Sort.String())
-					So(uv.Get(Type), ShouldEqual, vp.ReleaseType.String())
+					So(uv.Get(Type), ShouldEqual, vp.ReleaseTypes[0].Name())
+					So(uv.Get(Provisional.String()), ShouldEqual, "true")
+					So(uv.Get(Confirmed.String()), ShouldEqual, "true")
+					So(uv.Get(Postponed.String()), ShouldEqual, "true")
 					So(uv.Get(Census), ShouldEqual, "true")
 					So(uv.Get(Highlight), ShouldEqual, "")
 
 					Convey("And any validated parameters not needed are absent from the url.Values mapping", func() {
 						So(uv.Get(Offset), ShouldEqual, "")
 						So(uv.Get(Query), ShouldEqual, "")
-						So(uv.Get(Provisional.String()), ShouldEqual, "")
-						So(uv.Get(Confirmed.String()), ShouldEqual, "")
-						So(uv.Get(Postponed.String()), ShouldEqual, "")
 					})
 				})
 			})
@@ -85,6 +85,70 @@ func TestAsFrontendQuery(t *testing.T) {
 	})
 }
 
+func TestAsQueryReleaseTypes(t *testing.T) {
+	Convey("Given validated parameters with several release types", t, func() {
+		vp := ValidatedParams{
+			Limit:        10,
+			Page:         1,
+			Sort:         RelDateDesc,
+			ReleaseTypes: NewReleaseTypes(Cancelled, Upcoming, Cancelled),
+			Postponed:    true,
+		}
+
+		Convey("When we call AsFrontendQuery", func() {
+			uv := vp.AsFrontendQuery()
+
+			Convey("Then each release type is given once, in order", func() {
+				So(uv[Type], ShouldResemble, []string{"type-upcoming", "type-cancelled"})
+				So(uv.Get(Postponed.String()), ShouldEqual, "true")
+			})
+		})
+
+		Convey("When we call AsBackendQuery", func() {
+			uv := vp.AsBackendQuery()
+
+			Convey("Then each release type is given, and the date sort order is not inverted", func() {
+				So(uv[Type], ShouldResemble, []string{"type-upcoming", "type-cancelled"})
+				So(uv.Get(Postponed.String()), ShouldEqual, "true")
+				So(uv.Get(SortName), ShouldEqual, RelDateDesc.BackendString())
+			})
+		})
+	})
+}
+
+func TestAsFrontendQueryDatePreset(t *testing.T) {
+	Convey("Given validated parameters with dates resolved from a date preset", t, func() {
+		vp := ValidatedParams{
+			Limit:      10,
+			Page:       2,
+			AfterDate:  MustParseDate("2026-10-19"),
+			BeforeDate: MustParseDate("2026-10-25"),
+			DatePreset: ThisWeek,
+		}
+
+		Convey("When we call AsFrontendQuery", func() {
+			uv := vp.AsFrontendQuery()
+
+			Convey("Then the preset is kept instead of the dates it resolved to", func() {
+				So(uv.Get(DateRange), ShouldEqual, "this-week")
+				So(uv.Get(YearAfter), ShouldEqual, "")
+				So(uv.Get(DayAfter), ShouldEqual, "")
+				So(uv.Get(YearBefore), ShouldEqual, "")
+			})
+		})
+
+		Convey("When we call AsBackendQuery", func() {
+			uv := vp.AsBackendQuery()
+
+			Convey("Then the resolved dates are used", func() {
+				So(uv.Get(DateFrom), ShouldEqual, "2026-10-19")
+				So(uv.Get(DateTo), ShouldEqual, "2026-10-25")
+				So(uv.Get(DateRange), ShouldEqual, "")
+			})
+		})
+	})
+}
+
 func TestAsBackendQuery(t *testing.T) {
 	Convey("Given a set of validated parameters as a ValidatedParam struct", t, func() {
 		vp := ValidatedParams{
@@ -102,7 +166,7 @@ func TestAsBackendQuery(t *testing.T) {
 		}
 
 		Convey("And the release type is upcoming", func() {
-			vp.ReleaseType = Upcoming
+			vp.ReleaseTypes = NewReleaseTypes(Upcoming)
 			Convey("And we are sorting by date in ascending order", func() {
 				vp.Sort = RelDateAsc
 				Convey("When we call AsBackendQuery", func() {
@@ -114,7 +178,7 @@ func TestAsBackendQuery(t *testing.T) {
 						So(uv.Get(DateFrom), ShouldEqual, "2020-01-01")
 						So(uv.Get(DateTo), ShouldEqual, "2022-09-19")
 						So(uv.Get(Query), ShouldEqual, "some keywords")
-						So(uv.Get(Type), ShouldEqual, vp.ReleaseType.String())
+						So(uv.Get(Type), ShouldEqual, vp.ReleaseTypes[0].Name())
 						So(uv.Get(Provisional.String()), ShouldEqual, "true")
 						So(uv.Get(Confirmed.String()), ShouldEqual, "true")
 						So(uv.Get(Postponed.String()), ShouldEqual, "true")
@@ -141,7 +205,7 @@ func TestAsBackendQuery(t *testing.T) {
 						So(uv.Get(DateFrom), ShouldEqual, "2020-01-01")
 						So(uv.Get(DateTo), ShouldEqual, "2022-09-19")
 						So(uv.Get(Query), ShouldEqual, "some keywords")
-						So(uv.Get(Type), ShouldEqual, vp.ReleaseType.String())
+						So(uv.Get(Type), ShouldEqual, vp.ReleaseTypes[0].Name())
 						So(uv.Get(Provisional.String()), ShouldEqual, "true")
 						So(uv.Get(Confirmed.String()), ShouldEqual, "true")
 						So(uv.Get(Postponed.String()), ShouldEqual, "true")
@@ -160,7 +224,7 @@ func TestAsBackendQuery(t *testing.T) {
 		})
 
 		Convey("And the release type is not upcoming", func() {
-			vp.ReleaseType = Published
+			vp.ReleaseTypes = NewReleaseTypes(Published)
 
 			Convey("When we call AsBackendQuery", func() {
 				uv := vp.AsBackendQuery()
@@ -172,15 +236,15 @@ func TestAsBackendQuery(t *testing.T) {
 					So(uv.Get(DateTo), ShouldEqual, "2022-09-19")
 					So(uv.Get(Query), ShouldEqual, "some keywords")
 					So(uv.Get(SortName), ShouldEqual, vp.Sort.BackendString())
-					So(uv.Get(Type), ShouldEqual, vp.ReleaseType.String())
+					So(uv.Get(Type), ShouldEqual, vp.ReleaseTypes[0].Name())
+					So(uv.Get(Provisional.String()), ShouldEqual, "true")
+					So(uv.Get(Confirmed.String()), ShouldEqual, "true")
+					So(uv.Get(Postponed.String()), ShouldEqual, "true")
 					So(uv.Get(Census), ShouldEqual, "")
 					So(uv.Get(Highlight), ShouldEqual, "true")
 
 					Convey("And any validated parameters not needed are absent from the url.Values mapping", func() {
 						So(uv.Get(Keywords), ShouldEqual, "")
-						So(uv.Get(Provisional.String()), ShouldEqual, "")
-						So(uv.Get(Confirmed.String()), ShouldEqual, "")
-						So(uv.Get(Postponed.String()), ShouldEqual, "")
 					})
 				})
 			})
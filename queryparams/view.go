@@ -0,0 +1,118 @@
+package queryparams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	_ "time/tzdata" // release days are counted in Europe/London wherever the service runs
+
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// London is the time zone that release days are counted in
+var London = mustLoadLocation("Europe/London")
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic("unable to load time zone " + name + ": " + err.Error())
+	}
+	return loc
+}
+
+// LocalDate returns the date of t in Europe/London
+func LocalDate(t time.Time) Date {
+	y, m, d := t.In(London).Date()
+	return DateFromTime(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+}
+
+type View int
+
+const (
+	List View = iota
+	Month
+	Week
+)
+
+var viewValues = map[View]string{
+	List:  "list",
+	Month: "month",
+	Week:  "week",
+}
+
+func parseView(s string) (View, error) {
+	for v, name := range viewValues {
+		if strings.EqualFold(s, name) {
+			return v, nil
+		}
+	}
+
+	return List, errors.New("invalid view option string")
+}
+
+func (v View) String() string {
+	return viewValues[v]
+}
+
+// IsGrid reports whether the view shows a month or week of releases rather than a paginated list
+func (v View) IsGrid() bool {
+	return v == Month || v == Week
+}
+
+// Window returns the first and last days of the month, or the week from Monday to Sunday, containing date
+func (v View) Window(date time.Time) (start, end time.Time) {
+	y, m, d := date.Date()
+	switch v {
+	case Month:
+		start = time.Date(y, m, 1, 0, 0, 0, 0, date.Location())
+		return start, start.AddDate(0, 1, -1)
+	case Week:
+		// time.Weekday counts from Sunday, weeks here start on Monday
+		offset := (int(date.Weekday()) + 6) % 7
+		start = time.Date(y, m, d-offset, 0, 0, 0, 0, date.Location())
+		return start, start.AddDate(0, 0, 6)
+	default:
+		day := time.Date(y, m, d, 0, 0, 0, 0, date.Location())
+		return day, day
+	}
+}
+
+// Step returns the first day of the month or week before (n < 0) or after (n > 0) the one containing date
+func (v View) Step(date time.Time, n int) time.Time {
+	start, _ := v.Window(date)
+	if v == Month {
+		return start.AddDate(0, n, 0)
+	}
+	return start.AddDate(0, 0, 7*n)
+}
+
+// GetView validates and returns the "view" parameter
+func GetView(ctx context.Context, params url.Values) (View, error) {
+	asString := params.Get(ViewName)
+	if asString == "" {
+		return List, nil
+	}
+
+	view, err := parseView(asString)
+	if err != nil {
+		log.Warn(ctx, err.Error(), log.Data{logKeyParam: ViewName, logKeyValue: asString})
+		return List, fmt.Errorf("invalid %s parameter: %s", ViewName, err.Error())
+	}
+
+	return view, nil
+}
+
+// GetViewDate validates and returns the "date" parameter, which selects the month or week shown by a grid view
+func GetViewDate(ctx context.Context, params url.Values) (Date, error) {
+	asString := params.Get(ViewDate)
+	date, err := ParseDate(asString)
+	if err != nil {
+		log.Warn(ctx, "invalid date", log.Data{logKeyParam: ViewDate, logKeyValue: asString})
+		return Date{}, fmt.Errorf("invalid %s parameter: enter a date in the format YYYY-MM-DD", ViewDate)
+	}
+
+	return date, nil
+}
@@ -0,0 +1,113 @@
+package queryparams
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestViewWindow(t *testing.T) {
+	Convey("Given a set of dates and views", t, func() {
+		testcases := []struct {
+			description        string
+			view               View
+			date               string
+			exStart, exEnd     string
+			exPrevious, exNext string
+		}{
+			{
+				description: "a month view finds the first and last days of the month",
+				view:        Month, date: "2024-02-14",
+				exStart: "2024-02-01", exEnd: "2024-02-29",
+				exPrevious: "2024-01-01", exNext: "2024-03-01",
+			},
+			{
+				description: "a month view crosses the end of the year",
+				view:        Month, date: "2025-12-31",
+				exStart: "2025-12-01", exEnd: "2025-12-31",
+				exPrevious: "2025-11-01", exNext: "2026-01-01",
+			},
+			{
+				description: "a week view starts on the Monday",
+				view:        Week, date: "2026-10-21",
+				exStart: "2026-10-19", exEnd: "2026-10-25",
+				exPrevious: "2026-10-12", exNext: "2026-10-26",
+			},
+			{
+				description: "a Sunday is in the week that started the Monday before",
+				view:        Week, date: "2026-10-25",
+				exStart: "2026-10-19", exEnd: "2026-10-25",
+				exPrevious: "2026-10-12", exNext: "2026-10-26",
+			},
+		}
+
+		for _, tc := range testcases {
+			Convey(tc.description, func() {
+				date := MustParseDate(tc.date).Time()
+
+				start, end := tc.view.Window(date)
+				So(start.Format(DateFormat), ShouldEqual, tc.exStart)
+				So(end.Format(DateFormat), ShouldEqual, tc.exEnd)
+				So(tc.view.Step(date, -1).Format(DateFormat), ShouldEqual, tc.exPrevious)
+				So(tc.view.Step(date, 1).Format(DateFormat), ShouldEqual, tc.exNext)
+			})
+		}
+	})
+
+	Convey("Given a week that ends on the day the clocks go back", t, func() {
+		date := time.Date(2026, 10, 21, 0, 0, 0, 0, London)
+
+		Convey("The window starts and ends at local midnight", func() {
+			start, end := Week.Window(date)
+			So(start, ShouldEqual, time.Date(2026, 10, 19, 0, 0, 0, 0, London))
+			So(end, ShouldEqual, time.Date(2026, 10, 25, 0, 0, 0, 0, London))
+			So(Week.Step(date, 1), ShouldEqual, time.Date(2026, 10, 26, 0, 0, 0, 0, London))
+		})
+	})
+}
+
+func TestLocalDate(t *testing.T) {
+	Convey("Times are converted to their date in Europe/London", t, func() {
+		So(LocalDate(time.Date(2026, 7, 1, 23, 30, 0, 0, time.UTC)).String(), ShouldEqual, "2026-07-02")
+		So(LocalDate(time.Date(2026, 12, 1, 23, 30, 0, 0, time.UTC)).String(), ShouldEqual, "2026-12-01")
+	})
+}
+
+func TestGetView(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("The view defaults to a list", t, func() {
+		view, err := GetView(ctx, url.Values{})
+		So(err, ShouldBeNil)
+		So(view, ShouldEqual, List)
+		So(view.IsGrid(), ShouldBeFalse)
+	})
+
+	Convey("Month and week views are grids", t, func() {
+		view, err := GetView(ctx, url.Values{ViewName: []string{"month"}})
+		So(err, ShouldBeNil)
+		So(view, ShouldEqual, Month)
+		So(view.IsGrid(), ShouldBeTrue)
+
+		view, err = GetView(ctx, url.Values{ViewName: []string{"Week"}})
+		So(err, ShouldBeNil)
+		So(view, ShouldEqual, Week)
+	})
+
+	Convey("Other views are rejected", t, func() {
+		_, err := GetView(ctx, url.Values{ViewName: []string{"year"}})
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("The view date must be a date", t, func() {
+		date, err := GetViewDate(ctx, url.Values{ViewDate: []string{"2026-10-18"}})
+		So(err, ShouldBeNil)
+		So(date.String(), ShouldEqual, "2026-10-18")
+
+		_, err = GetViewDate(ctx, url.Values{ViewDate: []string{"18/10/2026"}})
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -26,14 +26,70 @@ type Clients struct {
 	ZebedeeClient      *zebedee.Client
 }
 
-// Setup registers routes for the service
-func Setup(ctx context.Context, r *mux.Router, cfg *config.Config, c Clients) {
+// Setup registers routes for the service. The handlers are built for each request from a snapshot of the current
+// config in store, so that the settings it reloads are used without registering the routes again.
+func Setup(ctx context.Context, r *mux.Router, store *config.Store, c Clients) {
 	log.Info(ctx, "adding routes")
+	r.Use(func(next http.Handler) http.Handler {
+		return current(store, func(cfg config.Config) http.Handler {
+			return handlers.NewDeprecations(cfg.RouteDeprecations()).Middleware(cfg.RoutingPrefix)(next)
+		})
+	}, func(next http.Handler) http.Handler {
+		return current(store, func(cfg config.Config) http.Handler {
+			return handlers.FeatureFlags(cfg)(next)
+		})
+	})
+
+	// the paths of the routes are not reloaded
+	cfg := store.Get()
+	// the sitemaps are kept across requests, as building them requests every release
+	sitemaps := handlers.NewSitemaps()
+
 	r.StrictSlash(true).Path("/health").HandlerFunc(c.HealthCheckHandler)
+	r.StrictSlash(true).Path(cfg.RoutingPrefix + "/metrics/deprecations").Methods("GET").HandlerFunc(handlers.DeprecationMetrics())
+
+	r.StrictSlash(true).Path(cfg.RoutingPrefix + "/releases/{uri}").Methods("GET").HandlerFunc(current(store, func(cfg config.Config) http.Handler {
+		return handlers.Release(cfg, c.Render, c.ReleaseCalendarAPI, c.ZebedeeClient)
+	}))
+	r.StrictSlash(true).Path(cfg.RoutingPrefix + "/releases/{uri}/data").Methods("GET").HandlerFunc(current(store, func(cfg config.Config) http.Handler {
+		return handlers.ReleaseData(cfg, c.ReleaseCalendarAPI)
+	}))
+	r.StrictSlash(true).Path(cfg.RoutingPrefix + "/releasecalendar").Methods("GET").HandlerFunc(current(store, func(cfg config.Config) http.Handler {
+		return handlers.ReleaseCalendar(cfg, c.Render, c.SearchAPI, c.ZebedeeClient)
+	}))
+	r.StrictSlash(true).Path(cfg.RoutingPrefix + "/releasecalendar/more").Methods("GET").HandlerFunc(current(store, func(cfg config.Config) http.Handler {
+		return handlers.ReleaseCalendarMore(cfg, c.Render, c.SearchAPI)
+	}))
+	r.StrictSlash(true).Path(cfg.RoutingPrefix + "/releasecalendar/export.{format}").Methods("GET").HandlerFunc(current(store, func(cfg config.Config) http.Handler {
+		return handlers.ReleaseCalendarExport(cfg, c.SearchAPI, c.ReleaseCalendarAPI)
+	}))
+	r.StrictSlash(true).Path(cfg.RoutingPrefix + "/releasecalendar/bulk").Methods("GET").HandlerFunc(current(store, func(cfg config.Config) http.Handler {
+		return handlers.ReleaseCalendarBulk(cfg, c.SearchAPI)
+	}))
+	r.StrictSlash(true).Path(cfg.RoutingPrefix + "/releasecalendar/data").Methods("GET").HandlerFunc(current(store, func(cfg config.Config) http.Handler {
+		return handlers.ReleaseCalendarData(cfg, c.SearchAPI)
+	}))
+	r.StrictSlash(true).Path(cfg.RoutingPrefix + "/releasecalendar/sitemap.xml").Methods("GET").HandlerFunc(current(store, func(cfg config.Config) http.Handler {
+		return handlers.SitemapIndex(cfg, c.SearchAPI, sitemaps)
+	}))
+	r.StrictSlash(true).Path(cfg.RoutingPrefix + "/releasecalendar/sitemap-{page:[0-9]+}.xml").Methods("GET").HandlerFunc(current(store, func(cfg config.Config) http.Handler {
+		return handlers.Sitemap(cfg, c.SearchAPI, sitemaps)
+	}))
+	r.StrictSlash(true).Path(cfg.ICSPath()).Methods("GET").HandlerFunc(current(store, func(cfg config.Config) http.Handler {
+		return handlers.ReleaseCalendarICSEntries(cfg, c.SearchAPI)
+	}))
+	r.StrictSlash(true).Path(cfg.RoutingPrefix + "/openapi.json").Methods("GET").HandlerFunc(handlers.OpenAPISpec())
+	r.StrictSlash(true).Path(cfg.APIPath() + "/releasecalendar").Methods("GET").HandlerFunc(current(store, func(cfg config.Config) http.Handler {
+		return handlers.APIReleaseCalendar(cfg, c.SearchAPI)
+	}))
+	r.StrictSlash(true).Path(cfg.APIPath() + "/releases/{uri}").Methods("GET").HandlerFunc(current(store, func(cfg config.Config) http.Handler {
+		return handlers.APIRelease(cfg, c.ReleaseCalendarAPI)
+	}))
+}
 
-	r.StrictSlash(true).Path(cfg.RoutingPrefix + "/releases/{uri}").Methods("GET").HandlerFunc(handlers.Release(*cfg, c.Render, c.ReleaseCalendarAPI, c.ZebedeeClient))
-	r.StrictSlash(true).Path(cfg.RoutingPrefix + "/releases/{uri}/data").Methods("GET").HandlerFunc(handlers.ReleaseData(*cfg, c.ReleaseCalendarAPI))
-	r.StrictSlash(true).Path(cfg.RoutingPrefix + "/releasecalendar").Methods("GET").HandlerFunc(handlers.ReleaseCalendar(*cfg, c.Render, c.SearchAPI, c.ZebedeeClient))
-	r.StrictSlash(true).Path(cfg.RoutingPrefix + "/releasecalendar/data").Methods("GET").HandlerFunc(handlers.ReleaseCalendarData(*cfg, c.SearchAPI))
-	r.StrictSlash(true).Path(cfg.RoutingPrefix + "/calendar/releasecalendar").Methods("GET").HandlerFunc(handlers.ReleaseCalendarICSEntries(*cfg, c.SearchAPI))
+// current returns a handler that serves each request with the handler built from a snapshot of the current config
+func current(store *config.Store, build func(cfg config.Config) http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		build(store.Get()).ServeHTTP(w, r)
+	}
 }
@@ -31,9 +31,12 @@ var (
 // Service contains the healthcheck, server and serviceList for the controller
 type Service struct {
 	Config      *config.Config
+	ConfigStore *config.Store
 	HealthCheck HealthChecker
 	Server      HTTPServer
 	ServiceList *ExternalServiceList
+
+	stopConfigWatch context.CancelFunc
 }
 
 // New creates a new service
@@ -48,6 +51,13 @@ func (svc *Service) Init(ctx context.Context, cfg *config.Config, serviceList *E
 	svc.Config = cfg
 	svc.ServiceList = serviceList
 
+	// Load the settings that can be reloaded while the service runs
+	svc.ConfigStore, err = config.NewStore(ctx, cfg)
+	if err != nil {
+		log.Error(ctx, "failed to load runtime config", err)
+		return err
+	}
+
 	// Get health client for api router
 	routerHealthClient := serviceList.GetHealthClient("api-router", cfg.APIRouterURL)
 
@@ -77,7 +87,7 @@ func (svc *Service) Init(ctx context.Context, cfg *config.Config, serviceList *E
 		renderror.Handler(clients.Render),
 	}
 	newAlice := alice.New(middleware...).Then(r)
-	routes.Setup(ctx, r, cfg, clients)
+	routes.Setup(ctx, r, svc.ConfigStore, clients)
 	svc.Server = serviceList.GetHTTPServer(cfg.BindAddr, newAlice)
 
 	return nil
@@ -90,6 +100,13 @@ func (svc *Service) Run(ctx context.Context, svcErrors chan error) {
 	// Start healthcheck
 	svc.HealthCheck.Start(ctx)
 
+	// Start reloading the runtime config
+	if svc.ConfigStore != nil {
+		var watchCtx context.Context
+		watchCtx, svc.stopConfigWatch = context.WithCancel(ctx)
+		go svc.ConfigStore.Watch(watchCtx)
+	}
+
 	// Start HTTP server
 	log.Info(ctx, "Starting server")
 	go func() {
@@ -113,6 +130,10 @@ func (svc *Service) Close(ctx context.Context) error {
 		log.Info(ctx, "stop health checkers")
 		svc.HealthCheck.Stop()
 
+		if svc.stopConfigWatch != nil {
+			svc.stopConfigWatch()
+		}
+
 		if err := svc.Server.Shutdown(ctx); err != nil {
 			log.Error(ctx, "failed to shutdown http server", err)
 			hasShutdownError = true
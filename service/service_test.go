@@ -110,6 +110,7 @@ func TestInitSuccess(t *testing.T) {
 
 				Convey("Then service is initialised successfully", func() {
 					So(svc.Config, ShouldResemble, cfg)
+					So(svc.ConfigStore.Get(), ShouldResemble, *cfg)
 					So(svc.HealthCheck, ShouldResemble, hcMock)
 					So(svc.Server, ShouldResemble, serverMock)
 					So(svc.ServiceList, ShouldResemble, mockServiceList)
@@ -0,0 +1,149 @@
+package sitemap
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// MaxURLs is the most URLs that a sitemap may list, and the most sitemaps that an index may list
+	MaxURLs = 50000
+	// MaxBytes is the largest that a sitemap or index may be before it is compressed
+	MaxBytes = 50 * 1024 * 1024
+
+	// ContentType is the media type that sitemaps and indexes are served with
+	ContentType = "application/xml; charset=utf-8"
+
+	urlsetStart = xml.Header + `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:xhtml="http://www.w3.org/1999/xhtml">` + "\n"
+	urlsetEnd   = "</urlset>\n"
+	indexStart  = xml.Header + `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` + "\n"
+	indexEnd    = "</sitemapindex>\n"
+)
+
+// URL is a page listed in a sitemap
+type URL struct {
+	Loc        string
+	LastMod    time.Time
+	Alternates []Alternate
+}
+
+// Alternate is the URL of a page in another language, given by its hreflang code
+type Alternate struct {
+	Hreflang string
+	Href     string
+}
+
+// Sitemap is a sitemap document, with the latest modification of the URLs it lists
+type Sitemap struct {
+	Data    []byte
+	LastMod time.Time
+}
+
+// Location is a sitemap listed in an index
+type Location struct {
+	Loc     string
+	LastMod time.Time
+}
+
+type xmlURL struct {
+	XMLName    xml.Name       `xml:"url"`
+	Loc        string         `xml:"loc"`
+	LastMod    string         `xml:"lastmod,omitempty"`
+	Alternates []xmlAlternate `xml:"xhtml:link"`
+}
+
+type xmlAlternate struct {
+	Rel      string `xml:"rel,attr"`
+	Hreflang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+type xmlLocation struct {
+	XMLName xml.Name `xml:"sitemap"`
+	Loc     string   `xml:"loc"`
+	LastMod string   `xml:"lastmod,omitempty"`
+}
+
+// Build returns the sitemaps that list the URLs in order. A new sitemap is started whenever the next URL would take
+// the current one past maxURLs URLs or MaxBytes, so that no sitemap is over the limits however long the URLs are.
+// maxURLs is capped at MaxURLs.
+func Build(urls []URL, maxURLs int) ([]Sitemap, error) {
+	if maxURLs < 1 || maxURLs > MaxURLs {
+		maxURLs = MaxURLs
+	}
+
+	var sitemaps []Sitemap
+	current := Sitemap{Data: []byte(urlsetStart)}
+	count := 0
+	for _, u := range urls {
+		b, err := xml.Marshal(u.xml())
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode sitemap URL %s: %w", u.Loc, err)
+		}
+		b = append(b, '\n')
+		if len(urlsetStart)+len(b)+len(urlsetEnd) > MaxBytes {
+			return nil, fmt.Errorf("sitemap URL %s is too large for a sitemap", u.Loc)
+		}
+
+		if count == maxURLs || len(current.Data)+len(b)+len(urlsetEnd) > MaxBytes {
+			current.Data = append(current.Data, urlsetEnd...)
+			sitemaps = append(sitemaps, current)
+			current, count = Sitemap{Data: []byte(urlsetStart)}, 0
+		}
+
+		current.Data = append(current.Data, b...)
+		count++
+		if u.LastMod.After(current.LastMod) {
+			current.LastMod = u.LastMod
+		}
+	}
+
+	if count > 0 {
+		current.Data = append(current.Data, urlsetEnd...)
+		sitemaps = append(sitemaps, current)
+	}
+	return sitemaps, nil
+}
+
+// Index returns the sitemap index that lists the locations
+func Index(locations []Location) ([]byte, error) {
+	if len(locations) > MaxURLs {
+		return nil, fmt.Errorf("%d sitemaps are more than an index may list", len(locations))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(indexStart)
+	for _, l := range locations {
+		b, err := xml.Marshal(xmlLocation{Loc: l.Loc, LastMod: formatLastMod(l.LastMod)})
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode sitemap location %s: %w", l.Loc, err)
+		}
+		buf.Write(b)
+		buf.WriteByte('\n')
+	}
+	buf.WriteString(indexEnd)
+
+	if buf.Len() > MaxBytes {
+		return nil, errors.New("sitemap index is larger than an index may be")
+	}
+	return buf.Bytes(), nil
+}
+
+func (u URL) xml() xmlURL {
+	x := xmlURL{Loc: u.Loc, LastMod: formatLastMod(u.LastMod)}
+	for _, a := range u.Alternates {
+		x.Alternates = append(x.Alternates, xmlAlternate{Rel: "alternate", Hreflang: a.Hreflang, Href: a.Href})
+	}
+	return x
+}
+
+// formatLastMod returns t in the W3C datetime format of sitemaps, or nothing if t is not set
+func formatLastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
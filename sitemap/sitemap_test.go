@@ -0,0 +1,147 @@
+package sitemap
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// urlset is a sitemap as read by a crawler
+type urlset struct {
+	URLs []struct {
+		Loc        string `xml:"loc"`
+		LastMod    string `xml:"lastmod"`
+		Alternates []struct {
+			Rel      string `xml:"rel,attr"`
+			Hreflang string `xml:"hreflang,attr"`
+			Href     string `xml:"href,attr"`
+		} `xml:"http://www.w3.org/1999/xhtml link"`
+	} `xml:"url"`
+}
+
+func generateURLs(count, pathLength int) []URL {
+	path := strings.Repeat("a", pathLength)
+	urls := make([]URL, 0, count)
+	for i := 0; i < count; i++ {
+		urls = append(urls, URL{Loc: fmt.Sprintf("https://www.ons.gov.uk/releases/%s%d", path, i)})
+	}
+	return urls
+}
+
+func countURLs(s Sitemap) int {
+	return bytes.Count(s.Data, []byte("<url>"))
+}
+
+func TestBuild(t *testing.T) {
+	Convey("Given a URL with a date and alternates", t, func() {
+		urls := []URL{{
+			Loc:     "https://www.ons.gov.uk/releases/gdp?a=1&b=2",
+			LastMod: time.Date(2026, 10, 21, 7, 0, 0, 0, time.FixedZone("BST", 3600)),
+			Alternates: []Alternate{
+				{Hreflang: "en", Href: "https://www.ons.gov.uk/releases/gdp"},
+				{Hreflang: "cy", Href: "https://cy.ons.gov.uk/releases/gdp"},
+			},
+		}}
+
+		sitemaps, err := Build(urls, MaxURLs)
+
+		Convey("Then it is listed in a sitemap that crawlers can read", func() {
+			So(err, ShouldBeNil)
+			So(sitemaps, ShouldHaveLength, 1)
+			So(sitemaps[0].LastMod, ShouldEqual, urls[0].LastMod)
+
+			var set urlset
+			So(xml.Unmarshal(sitemaps[0].Data, &set), ShouldBeNil)
+			So(set.URLs, ShouldHaveLength, 1)
+			So(set.URLs[0].Loc, ShouldEqual, "https://www.ons.gov.uk/releases/gdp?a=1&b=2")
+			So(set.URLs[0].LastMod, ShouldEqual, "2026-10-21T06:00:00Z")
+			So(set.URLs[0].Alternates, ShouldHaveLength, 2)
+			So(set.URLs[0].Alternates[1].Rel, ShouldEqual, "alternate")
+			So(set.URLs[0].Alternates[1].Hreflang, ShouldEqual, "cy")
+			So(set.URLs[0].Alternates[1].Href, ShouldEqual, "https://cy.ons.gov.uk/releases/gdp")
+		})
+	})
+
+	Convey("Given no URLs", t, func() {
+		Convey("Then there are no sitemaps", func() {
+			sitemaps, err := Build(nil, MaxURLs)
+			So(err, ShouldBeNil)
+			So(sitemaps, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given more URLs than a sitemap may list", t, func() {
+		sitemaps, err := Build(generateURLs(MaxURLs+1, 0), MaxURLs*2)
+
+		Convey("Then they are split so that no sitemap lists more than 50,000", func() {
+			So(err, ShouldBeNil)
+			So(sitemaps, ShouldHaveLength, 2)
+			So(countURLs(sitemaps[0]), ShouldEqual, MaxURLs)
+			So(countURLs(sitemaps[1]), ShouldEqual, 1)
+		})
+	})
+
+	Convey("Given URLs long enough that 50,000 of them would be over 50MB", t, func() {
+		sitemaps, err := Build(generateURLs(MaxURLs, 1024), MaxURLs)
+
+		Convey("Then they are split so that no sitemap is over 50MB", func() {
+			So(err, ShouldBeNil)
+			So(len(sitemaps), ShouldBeGreaterThan, 1)
+
+			total := 0
+			for _, s := range sitemaps {
+				So(len(s.Data), ShouldBeLessThanOrEqualTo, MaxBytes)
+				So(string(s.Data), ShouldEndWith, "</urlset>\n")
+				total += countURLs(s)
+			}
+			So(total, ShouldEqual, MaxURLs)
+			So(len(sitemaps[0].Data), ShouldBeGreaterThan, MaxBytes-2048)
+		})
+	})
+
+	Convey("Given a smaller number of URLs per sitemap", t, func() {
+		Convey("Then the sitemaps list no more than that number", func() {
+			sitemaps, err := Build(generateURLs(5, 0), 2)
+			So(err, ShouldBeNil)
+			So(sitemaps, ShouldHaveLength, 3)
+			So(countURLs(sitemaps[2]), ShouldEqual, 1)
+		})
+	})
+}
+
+func TestIndex(t *testing.T) {
+	Convey("Given the locations of sitemaps", t, func() {
+		index, err := Index([]Location{
+			{Loc: "https://www.ons.gov.uk/releasecalendar/sitemap-1.xml", LastMod: time.Date(2026, 10, 21, 6, 0, 0, 0, time.UTC)},
+			{Loc: "https://www.ons.gov.uk/releasecalendar/sitemap-2.xml"},
+		})
+
+		Convey("Then the index lists them", func() {
+			So(err, ShouldBeNil)
+
+			var set struct {
+				Sitemaps []struct {
+					Loc     string `xml:"loc"`
+					LastMod string `xml:"lastmod"`
+				} `xml:"sitemap"`
+			}
+			So(xml.Unmarshal(index, &set), ShouldBeNil)
+			So(set.Sitemaps, ShouldHaveLength, 2)
+			So(set.Sitemaps[0].LastMod, ShouldEqual, "2026-10-21T06:00:00Z")
+			So(set.Sitemaps[1].Loc, ShouldEqual, "https://www.ons.gov.uk/releasecalendar/sitemap-2.xml")
+			So(string(index), ShouldNotContainSubstring, "<lastmod></lastmod>")
+		})
+	})
+
+	Convey("Given more sitemaps than an index may list", t, func() {
+		Convey("Then no index is returned", func() {
+			_, err := Index(make([]Location, MaxURLs+1))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}